@@ -6,34 +6,135 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/Vallghall/gopherscm/internal/core/process"
+	"github.com/Vallghall/gopherscm/internal/core/stdio"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
 	"github.com/Vallghall/gopherscm/internal/interp"
 	"github.com/Vallghall/gopherscm/internal/lexer"
 	"github.com/Vallghall/gopherscm/internal/parser"
+	"github.com/Vallghall/gopherscm/internal/repl"
 )
 
 func main() {
 	ops := getOptions()
+	scripts, args := parseArgs(os.Args[1:])
 
-	// going simple for now
-	if len(flag.Args()) < 1 {
-		log.Fatalln("Expected file name")
+	program, script := os.Args[0], ""
+	if len(scripts) > 0 {
+		program, script = scripts[0], scripts[0]
 	}
 
-	bs, err := os.ReadFile(flag.Arg(0))
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			log.Fatalln("** FILE NOT FOUND **")
+	process.SetCommandLine(append([]string{program}, args...)...)
+
+	if len(scripts) == 0 && len(*ops.exprs) == 0 {
+		interp.SetSearchPath(searchPath(ops, "")...)
+		exit(repl.New(os.Stdin, os.Stdout).Run())
+	}
+
+	interp.SetSearchPath(searchPath(ops, script)...)
+
+	// scripts and expressions share the environment
+	ctx := data.NewProgramContext()
+	for _, script := range scripts {
+		if err := runScript(ops, script, ctx); err != nil {
+			exit(err)
+		}
+	}
+
+	for _, expr := range *ops.exprs {
+		if err := evalExpr(expr, ctx); err != nil {
+			exit(err)
+		}
+	}
+
+	exit(nil)
+}
+
+// exit - flushes the output and exits with the status requested
+// by the program, uncaught errors are reported with status 1
+func exit(err error) {
+	var ee *errscm.ExitError
+	switch {
+	case errors.As(err, &ee):
+		if !ee.Emergency {
+			_ = stdio.Flush()
 		}
 
-		log.Fatalln(err)
+		os.Exit(ee.Code)
+	case err != nil:
+		_ = stdio.Flush()
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	_ = stdio.Flush()
+	os.Exit(0)
+}
+
+// parseArgs - parses flags interleaved with the scripts,
+// the arguments following "--" are left for the program
+func parseArgs(rest []string) ([]string, []string) {
+	scripts := make([]string, 0)
+	for len(rest) > 0 {
+		switch {
+		case rest[0] == "--":
+			return scripts, rest[1:]
+		case rest[0] == "-" || !strings.HasPrefix(rest[0], "-"):
+			scripts = append(scripts, rest[0])
+			rest = rest[1:]
+			continue
+		}
+
+		// flag package would consume "--" itself
+		end := len(rest)
+		for i, arg := range rest {
+			if arg == "--" {
+				end = i
+				break
+			}
+		}
+
+		_ = flag.CommandLine.Parse(rest[:end])
+		rest = append(append([]string{}, flag.Args()...), rest[end:]...)
+	}
+
+	return scripts, nil
+}
+
+// readScript - reads source of the script, "-" stands for
+// the standard input. Returns the source along with the
+// file name its positions refer to
+func readScript(script string) ([]rune, string, error) {
+	if script == "-" {
+		bs, err := io.ReadAll(os.Stdin)
+		return bytes.Runes(bs), "<stdin>", err
+	}
+
+	bs, err := os.ReadFile(script)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", fmt.Errorf("** FILE NOT FOUND **: %s", script)
 	}
 
-	ts, err := lexer.Lex(bytes.Runes(bs))
+	return bytes.Runes(bs), script, err
+}
+
+// runScript - evaluates the script within the context
+func runScript(ops *options, script string, ctx *data.Context) error {
+	src, file, err := readScript(script)
 	if err != nil {
-		log.Fatalln(err)
+		return err
+	}
+
+	ts, err := lexer.LexFile(src, file)
+	if err != nil {
+		return err
 	}
 
 	if *ops.lexOut {
@@ -46,25 +147,84 @@ func main() {
 		writeIntermediateResults("parse", ast)
 	}
 
+	ast.Ctx = ctx
 	_, err = interp.Walk(ast)
+	return err
+}
+
+// evalExpr - evaluates the expression given with -e
+// within the context and writes its result
+func evalExpr(expr string, ctx *data.Context) error {
+	ts, err := lexer.Lex([]rune(expr))
+	if err != nil {
+		return err
+	}
+
+	ast := parser.Parse(ts)
+	ast.Ctx = ctx
+	result, err := interp.Walk(ast)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
+
+	repl.Print(os.Stdout, result)
+	return nil
 }
 
 // options - wrapper around flag options
 type options struct {
 	lexOut   *bool
 	parseOut *bool
+	includes *repeated
+	exprs    *repeated
 }
 
-// getOptions - helper for retrieving flag values
+// repeated - flag collecting the values of its repetitions
+type repeated []string
+
+func (r *repeated) String() string {
+	return strings.Join(*r, " ")
+}
+
+func (r *repeated) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+// getOptions - helper for defining flags, their values
+// are set once the arguments are parsed
 func getOptions() *options {
-	defer flag.Parse()
-	return &options{
+	ops := &options{
 		lexOut:   flag.Bool("L", false, "logs lexer's results into a lex.out.json file"),
 		parseOut: flag.Bool("P", false, "logs parser's results into a parse.out.json file"),
+		includes: new(repeated),
+		exprs:    new(repeated),
+	}
+
+	flag.Var(ops.includes, "I", "adds directory to the library search path, may be repeated")
+	flag.Var(ops.exprs, "e", "evaluates the expression after the scripts and prints its result, may be repeated")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [script ...] [-- arg ...]\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "scripts are evaluated in order in one environment, - reads the standard input,")
+		fmt.Fprintln(flag.CommandLine.Output(), "the REPL is started if neither scripts nor expressions are given")
+		flag.PrintDefaults()
 	}
+
+	return ops
+}
+
+// searchPath - directories searched for the libraries: the ones given
+// with -I flags, then the ones listed in GOPHERSCM_PATH and the
+// directory of the main script, the working one without scripts
+func searchPath(ops *options, script string) []string {
+	dirs := append([]string{}, *ops.includes...)
+	for _, dir := range filepath.SplitList(os.Getenv("GOPHERSCM_PATH")) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return append(dirs, filepath.Dir(script))
 }
 
 // writeIntermediateResults - helper for writing intermediate results,
@@ -83,6 +83,10 @@ func Divide(args ...types.Object) (types.Object, error) {
 		return nil, errscm.ErrNaN
 	}
 	b := args[1]
+	if n, ok := b.(*types.Number); ok && n.IsInt() && n.Int() == 0 {
+		return nil, fmt.Errorf("%w: %v / 0", errscm.ErrDivisionByZero, a)
+	}
+
 	result, err := a.ApplyOperation(operator.Division, b)
 	if err != nil {
 		return nil, err
@@ -90,3 +94,54 @@ func Divide(args ...types.Object) (types.Object, error) {
 
 	return result, nil
 }
+
+// Equal – `=` primitive
+func Equal(args ...types.Object) (types.Object, error) {
+	return compare(args, func(c int) bool { return c == 0 })
+}
+
+// Less – `<` primitive
+func Less(args ...types.Object) (types.Object, error) {
+	return compare(args, func(c int) bool { return c < 0 })
+}
+
+// Greater – `>` primitive
+func Greater(args ...types.Object) (types.Object, error) {
+	return compare(args, func(c int) bool { return c > 0 })
+}
+
+// LessOrEqual – `<=` primitive
+func LessOrEqual(args ...types.Object) (types.Object, error) {
+	return compare(args, func(c int) bool { return c <= 0 })
+}
+
+// GreaterOrEqual – `>=` primitive
+func GreaterOrEqual(args ...types.Object) (types.Object, error) {
+	return compare(args, func(c int) bool { return c >= 0 })
+}
+
+// compare - checks that every pair of adjacent arguments
+// satisfies the predicate over their comparison result
+func compare(args []types.Object, pred func(int) bool) (types.Object, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: expected at least 2 arguments, got: %d", errscm.ErrTooLittleArguments, len(args))
+	}
+
+	result := true
+	for i := 0; i < len(args)-1; i++ {
+		a, ok := args[i].(*types.Number)
+		if !ok {
+			return nil, errscm.ErrNaN
+		}
+
+		c, err := a.Compare(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		// keep checking the rest of arguments to report non-numbers
+		result = result && pred(c)
+	}
+
+	return types.Boolean(result), nil
+}
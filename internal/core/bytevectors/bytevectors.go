@@ -0,0 +1,262 @@
+package bytevectors
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// BytevectorOp - wrapper for builtin bytevector procedures
+type BytevectorOp func(args ...types.Object) (types.Object, error)
+
+func (b BytevectorOp) Call(args ...types.Object) (types.Object, error) {
+	return b(args...)
+}
+
+func (b BytevectorOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// Bytevector - `bytevector` primitive
+func Bytevector(args ...types.Object) (types.Object, error) {
+	bs := make([]byte, len(args))
+	for i, arg := range args {
+		u8, err := Byte(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		bs[i] = u8
+	}
+
+	return types.NewBytevector(bs), nil
+}
+
+// MakeBytevector - `make-bytevector` primitive
+func MakeBytevector(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	k, err := check.Length(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var fill byte
+	if len(args) == 2 {
+		if fill, err = Byte(args[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	bs := make([]byte, k)
+	for i := range bs {
+		bs[i] = fill
+	}
+
+	return types.NewBytevector(bs), nil
+}
+
+// BytevectorRef - `bytevector-u8-ref` primitive
+func BytevectorRef(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	bv, err := bytevector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := check.Index(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	u8, err := bv.Ref(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(int64(u8)), nil
+}
+
+// BytevectorSet - `bytevector-u8-set!` primitive
+func BytevectorSet(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 3, 3); err != nil {
+		return nil, err
+	}
+
+	bv, err := bytevector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := check.Index(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	u8, err := Byte(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, bv.Set(k, u8)
+}
+
+// BytevectorLength - `bytevector-length` primitive
+func BytevectorLength(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	bv, err := bytevector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(int64(bv.Len())), nil
+}
+
+// BytevectorCopy - `bytevector-copy` primitive
+func BytevectorCopy(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 3); err != nil {
+		return nil, err
+	}
+
+	bv, err := bytevector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(bv.Len(), args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	bs := make([]byte, end-start)
+	copy(bs, bv.Bytes()[start:end])
+	return types.NewBytevector(bs), nil
+}
+
+// BytevectorCopyTo - `bytevector-copy!` primitive, copies bytes of
+// `from` bytevector into `to` bytevector starting at index `at`
+func BytevectorCopyTo(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 3, 5); err != nil {
+		return nil, err
+	}
+
+	to, err := bytevector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := check.Index(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := bytevector(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(from.Len(), args[3:])
+	if err != nil {
+		return nil, err
+	}
+
+	if at > to.Len() || to.Len()-at < end-start {
+		return nil, fmt.Errorf(
+			"%w: cannot copy %d bytes at index %d, bytevector length %d",
+			errscm.ErrIndexOutOfRange, end-start, at, to.Len(),
+		)
+	}
+
+	copy(to.Bytes()[at:], from.Bytes()[start:end])
+	return nil, nil
+}
+
+// BytevectorAppend - `bytevector-append` primitive
+func BytevectorAppend(args ...types.Object) (types.Object, error) {
+	bs := make([]byte, 0)
+	for _, arg := range args {
+		bv, err := bytevector(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, bv.Bytes()...)
+	}
+
+	return types.NewBytevector(bs), nil
+}
+
+// UTF8ToString - `utf8->string` primitive
+func UTF8ToString(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 3); err != nil {
+		return nil, err
+	}
+
+	bv, err := bytevector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(bv.Len(), args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	bs := bv.Bytes()[start:end]
+	if !utf8.Valid(bs) {
+		return nil, fmt.Errorf("%w: bytes are not valid UTF-8", errscm.ErrInvalidEncoding)
+	}
+
+	return types.String(bs), nil
+}
+
+// StringToUTF8 - `string->utf8` primitive
+func StringToUTF8(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 3); err != nil {
+		return nil, err
+	}
+
+	s, ok := args[0].(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected string, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	runes := []rune(string(s))
+	start, end, err := check.Range(len(runes), args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewBytevector([]byte(string(runes[start:end]))), nil
+}
+
+// Byte - asserts that the object is an integer in range [0, 255]
+func Byte(obj types.Object) (byte, error) {
+	n, ok := obj.(*types.Number)
+	if !ok || !n.IsInt() || n.Int() < 0 || n.Int() > 255 {
+		return 0, fmt.Errorf("%w: expected byte, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	return byte(n.Int()), nil
+}
+
+// bytevector - asserts that the object is a bytevector
+func bytevector(obj types.Object) (*types.Bytevector, error) {
+	bv, ok := obj.(*types.Bytevector)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected bytevector, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	return bv, nil
+}
@@ -0,0 +1,53 @@
+package chars
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// CharOp - wrapper for builtin case conversion procedures
+type CharOp func(args ...types.Object) (types.Object, error)
+
+func (c CharOp) Call(args ...types.Object) (types.Object, error) {
+	return c(args...)
+}
+
+func (c CharOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// StringUpcase - `string-upcase` primitive
+func StringUpcase(args ...types.Object) (types.Object, error) {
+	return convert(args, strings.ToUpper)
+}
+
+// StringDowncase - `string-downcase` primitive
+func StringDowncase(args ...types.Object) (types.Object, error) {
+	return convert(args, strings.ToLower)
+}
+
+// StringFoldcase - `string-foldcase` primitive, folds the case
+// the way it is done for case-insensitive comparisons
+func StringFoldcase(args ...types.Object) (types.Object, error) {
+	return convert(args, func(s string) string {
+		return strings.ToLower(strings.ToUpper(s))
+	})
+}
+
+// convert - applies conversion to the only string argument
+func convert(args []types.Object, fn func(string) string) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	s, ok := args[0].(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected string, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return types.String(fn(string(s))), nil
+}
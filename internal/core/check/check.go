@@ -0,0 +1,80 @@
+package check
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// Arity - checks that number of arguments is within [min, max]
+func Arity(args []types.Object, min, max int) error {
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return fmt.Errorf("%w: expected %d args, got %d", errscm.ErrUnexpectedNumberOfArguments, min, len(args))
+		}
+
+		return fmt.Errorf("%w: expected from %d to %d args, got %d", errscm.ErrUnexpectedNumberOfArguments, min, max, len(args))
+	}
+
+	return nil
+}
+
+// Index - asserts that the object is a non-negative integer
+func Index(obj types.Object) (int, error) {
+	n, ok := obj.(*types.Number)
+	if !ok || !n.IsInt() {
+		return 0, fmt.Errorf("%w: expected exact integer, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	if n.Int() < 0 {
+		return 0, fmt.Errorf("%w: negative index %d", errscm.ErrIndexOutOfRange, n.Int())
+	}
+
+	return int(n.Int()), nil
+}
+
+// MaxLength - the largest length of vectors and bytevectors
+// created at once, larger ones would exhaust the memory
+const MaxLength = 1 << 24
+
+// Length - asserts that the object is a non-negative integer
+// usable as the length of a newly allocated sequence
+func Length(obj types.Object) (int, error) {
+	k, err := Index(obj)
+	if err != nil {
+		return 0, err
+	}
+
+	if k > MaxLength {
+		return 0, fmt.Errorf("%w: length %d exceeds the limit of %d", errscm.ErrIndexOutOfRange, k, MaxLength)
+	}
+
+	return k, nil
+}
+
+// Range - parses optional start and end arguments,
+// checking them against the length of a sequence
+func Range(length int, args []types.Object) (start, end int, err error) {
+	end = length
+	if len(args) > 0 {
+		if start, err = Index(args[0]); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if len(args) > 1 {
+		if end, err = Index(args[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if end > length || start > end {
+		return 0, 0, fmt.Errorf(
+			"%w: range [%d, %d) for length %d",
+			errscm.ErrIndexOutOfRange, start, end, length,
+		)
+	}
+
+	return start, end, nil
+}
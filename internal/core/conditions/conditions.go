@@ -0,0 +1,134 @@
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// ConditionOp - wrapper for builtin procedures operating on error objects
+type ConditionOp func(args ...types.Object) (types.Object, error)
+
+func (c ConditionOp) Call(args ...types.Object) (types.Object, error) {
+	return c(args...)
+}
+
+func (c ConditionOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// readErrors - errors of reading the source code
+var readErrors = []error{
+	errscm.ErrMissingMatchingDoubleQuotes,
+	errscm.ErrInvalidNumericLiteral,
+	errscm.ErrInvalidSymbol,
+	errscm.ErrFreeClosingParenthesis,
+	errscm.ErrMissingClosingParenthesis,
+	errscm.ErrUnexpectedLineBreak,
+	errscm.ErrUnexpectedDotSymbol,
+}
+
+// Error - `error` primitive: (error message irritant...)
+// raises a new error object
+func Error(args ...types.Object) (types.Object, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: expected at least 1 arg, got 0", errscm.ErrTooLittleArguments)
+	}
+
+	message, ok := args[0].(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected string message, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	irritants := make([]types.Object, len(args)-1)
+	copy(irritants, args[1:])
+
+	return nil, &types.ErrorObject{
+		Message:   string(message),
+		Irritants: irritants,
+	}
+}
+
+// IsErrorObject - `error-object?` primitive
+func IsErrorObject(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	_, ok := args[0].(*types.ErrorObject)
+	return types.Boolean(ok), nil
+}
+
+// ErrorObjectMessage - `error-object-message` primitive
+func ErrorObjectMessage(args ...types.Object) (types.Object, error) {
+	obj, err := errorObject(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.String(obj.Message), nil
+}
+
+// ErrorObjectIrritants - `error-object-irritants` primitive
+func ErrorObjectIrritants(args ...types.Object) (types.Object, error) {
+	obj, err := errorObject(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.List(obj.Irritants...), nil
+}
+
+// IsFileError - `file-error?` primitive, reports errors
+// of opening files
+func IsFileError(args ...types.Object) (types.Object, error) {
+	return isErrorOf(args, func(err error) bool {
+		var pathErr *fs.PathError
+		return errors.As(err, &pathErr) ||
+			errors.Is(err, fs.ErrNotExist) ||
+			errors.Is(err, fs.ErrPermission)
+	})
+}
+
+// IsReadError - `read-error?` primitive, reports errors
+// of reading the source code
+func IsReadError(args ...types.Object) (types.Object, error) {
+	return isErrorOf(args, func(err error) bool {
+		for _, readErr := range readErrors {
+			if errors.Is(err, readErr) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// isErrorOf - reports whether the argument is an error object
+// made of the Go error satisfying the predicate
+func isErrorOf(args []types.Object, pred func(error) bool) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	obj, ok := args[0].(*types.ErrorObject)
+	return types.Boolean(ok && obj.Err != nil && pred(obj.Err)), nil
+}
+
+// errorObject - asserts that the only argument is an error object
+func errorObject(args []types.Object) (*types.ErrorObject, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	obj, ok := args[0].(*types.ErrorObject)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected error object, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return obj, nil
+}
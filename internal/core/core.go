@@ -1,23 +1,226 @@
 package core
 
 import (
+	"sort"
+
 	"github.com/Vallghall/gopherscm/internal/core/arithmetics"
+	"github.com/Vallghall/gopherscm/internal/core/bytevectors"
+	"github.com/Vallghall/gopherscm/internal/core/chars"
+	"github.com/Vallghall/gopherscm/internal/core/conditions"
+	"github.com/Vallghall/gopherscm/internal/core/equivalence"
+	"github.com/Vallghall/gopherscm/internal/core/hashtables"
+	"github.com/Vallghall/gopherscm/internal/core/lists"
+	"github.com/Vallghall/gopherscm/internal/core/procedures"
+	"github.com/Vallghall/gopherscm/internal/core/process"
 	"github.com/Vallghall/gopherscm/internal/core/stdio"
+	"github.com/Vallghall/gopherscm/internal/core/symbols"
 	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/core/vectors"
 )
 
-// DefaultDefinitions - returns a symbol table with builtin definitions
-func DefaultDefinitions() map[string]types.Object {
-	return map[string]types.Object{
+// libraries - builtin definitions grouped by the standard
+// libraries providing them, keyed by the library name parts
+// joined with spaces, e.g. "scheme base" for (scheme base)
+var libraries = map[string]map[string]types.Object{
+	"scheme base": {
 		// Arithmetics
 		"+": arithmetics.Primitive(arithmetics.Plus),
 		"-": arithmetics.Primitive(arithmetics.Minus),
 		"*": arithmetics.Primitive(arithmetics.Multiply),
 		"/": arithmetics.Primitive(arithmetics.Divide),
 
+		// Numeric comparison
+		"=":  arithmetics.Primitive(arithmetics.Equal),
+		"<":  arithmetics.Primitive(arithmetics.Less),
+		">":  arithmetics.Primitive(arithmetics.Greater),
+		"<=": arithmetics.Primitive(arithmetics.LessOrEqual),
+		">=": arithmetics.Primitive(arithmetics.GreaterOrEqual),
+
+		// Procedures
+		"procedure?":     procedures.ProcedureOp(procedures.IsProcedure),
+		"values":         procedures.ProcedureOp(procedures.Values),
+		"make-parameter": procedures.ProcedureOp(procedures.MakeParameter),
+
+		// Equivalence predicates
+		"eq?":    equivalence.EquivalenceOp(equivalence.IsEqv),
+		"eqv?":   equivalence.EquivalenceOp(equivalence.IsEqv),
+		"equal?": equivalence.EquivalenceOp(equivalence.IsEqual),
+
+		// Pairs and lists
+		"cons": lists.ListOp(lists.Cons),
+		"car":  lists.ListOp(lists.Car),
+		"cdr":  lists.ListOp(lists.Cdr),
+		"list": lists.ListOp(lists.List),
+
+		// Symbols
+		"symbol?":        symbols.SymbolOp(symbols.IsSymbol),
+		"symbol->string": symbols.SymbolOp(symbols.SymbolToString),
+		"string->symbol": symbols.SymbolOp(symbols.StringToSymbol),
+
+		// Vectors
+		"vector":          vectors.VectorOp(vectors.Vector),
+		"make-vector":     vectors.VectorOp(vectors.MakeVector),
+		"vector-ref":      vectors.VectorOp(vectors.VectorRef),
+		"vector-set!":     vectors.VectorOp(vectors.VectorSet),
+		"vector-length":   vectors.VectorOp(vectors.VectorLength),
+		"vector->list":    vectors.VectorOp(vectors.VectorToList),
+		"list->vector":    vectors.VectorOp(vectors.ListToVector),
+		"vector-fill!":    vectors.VectorOp(vectors.VectorFill),
+		"vector-copy":     vectors.VectorOp(vectors.VectorCopy),
+		"vector-copy!":    vectors.VectorOp(vectors.VectorCopyTo),
+		"vector-append":   vectors.VectorOp(vectors.VectorAppend),
+		"vector-map":      vectors.VectorOp(vectors.VectorMap),
+		"vector-for-each": vectors.VectorOp(vectors.VectorForEach),
+
+		// Bytevectors
+		"bytevector":         bytevectors.BytevectorOp(bytevectors.Bytevector),
+		"make-bytevector":    bytevectors.BytevectorOp(bytevectors.MakeBytevector),
+		"bytevector-u8-ref":  bytevectors.BytevectorOp(bytevectors.BytevectorRef),
+		"bytevector-u8-set!": bytevectors.BytevectorOp(bytevectors.BytevectorSet),
+		"bytevector-length":  bytevectors.BytevectorOp(bytevectors.BytevectorLength),
+		"bytevector-copy":    bytevectors.BytevectorOp(bytevectors.BytevectorCopy),
+		"bytevector-copy!":   bytevectors.BytevectorOp(bytevectors.BytevectorCopyTo),
+		"bytevector-append":  bytevectors.BytevectorOp(bytevectors.BytevectorAppend),
+		"utf8->string":       bytevectors.BytevectorOp(bytevectors.UTF8ToString),
+		"string->utf8":       bytevectors.BytevectorOp(bytevectors.StringToUTF8),
+
+		// Error objects
+		"error":                  conditions.ConditionOp(conditions.Error),
+		"error-object?":          conditions.ConditionOp(conditions.IsErrorObject),
+		"error-object-message":   conditions.ConditionOp(conditions.ErrorObjectMessage),
+		"error-object-irritants": conditions.ConditionOp(conditions.ErrorObjectIrritants),
+		"file-error?":            conditions.ConditionOp(conditions.IsFileError),
+		"read-error?":            conditions.ConditionOp(conditions.IsReadError),
+
 		// Standart output
-		"display":   stdio.IOHandler(stdio.Display),
-		"newline":   stdio.IOHandler(stdio.NewLine),
-		"displayln": stdio.IOHandler(stdio.Displayln),
+		"current-output-port": stdio.CurrentOutputPort,
+		"open-output-string":  stdio.IOHandler(stdio.OpenOutputString),
+		"get-output-string":   stdio.IOHandler(stdio.GetOutputString),
+		"newline":             stdio.IOHandler(stdio.NewLine),
+		"flush-output-port":   stdio.IOHandler(stdio.FlushOutputPort),
+
+		// Binary ports
+		"open-input-bytevector":  stdio.IOHandler(stdio.OpenInputBytevector),
+		"open-output-bytevector": stdio.IOHandler(stdio.OpenOutputBytevector),
+		"get-output-bytevector":  stdio.IOHandler(stdio.GetOutputBytevector),
+		"read-u8":                stdio.IOHandler(stdio.ReadU8),
+		"peek-u8":                stdio.IOHandler(stdio.PeekU8),
+		"write-u8":               stdio.IOHandler(stdio.WriteU8),
+		"eof-object":             stdio.IOHandler(stdio.EOFObject),
+		"eof-object?":            stdio.IOHandler(stdio.IsEOFObject),
+	},
+	"scheme write": {
+		"display": stdio.IOHandler(stdio.Display),
+		"write":   stdio.IOHandler(stdio.Write),
+	},
+	"scheme char": {
+		"string-upcase":   chars.CharOp(chars.StringUpcase),
+		"string-downcase": chars.CharOp(chars.StringDowncase),
+		"string-foldcase": chars.CharOp(chars.StringFoldcase),
+	},
+	"scheme process-context": {
+		"command-line":              process.ProcessOp(process.CommandLine),
+		"exit":                      process.ProcessOp(process.Exit),
+		"emergency-exit":            process.ProcessOp(process.EmergencyExit),
+		"get-environment-variable":  process.ProcessOp(process.GetEnvironmentVariable),
+		"get-environment-variables": process.ProcessOp(process.GetEnvironmentVariables),
+	},
+	"srfi 69":  hashTables,
+	"srfi 125": hashTables,
+	"gopherscm": {
+		"procedure-arity": procedures.ProcedureOp(procedures.ProcedureArity),
+		"symbol-append":   symbols.SymbolOp(symbols.SymbolAppend),
+		"displayln":       stdio.IOHandler(stdio.Displayln),
+	},
+}
+
+// hashTables - hash table procedures shared by SRFI 69 and SRFI 125
+var hashTables = map[string]types.Object{
+	"make-hash-table":            hashtables.HashTableOp(hashtables.MakeHashTable),
+	"hash-table?":                hashtables.HashTableOp(hashtables.IsHashTable),
+	"hash-table-ref":             hashtables.HashTableOp(hashtables.HashTableRef),
+	"hash-table-ref/default":     hashtables.HashTableOp(hashtables.HashTableRefDefault),
+	"hash-table-set!":            hashtables.HashTableOp(hashtables.HashTableSet),
+	"hash-table-delete!":         hashtables.HashTableOp(hashtables.HashTableDelete),
+	"hash-table-contains?":       hashtables.HashTableOp(hashtables.HashTableContains),
+	"hash-table-exists?":         hashtables.HashTableOp(hashtables.HashTableContains),
+	"hash-table-update!":         hashtables.HashTableOp(hashtables.HashTableUpdate),
+	"hash-table-update!/default": hashtables.HashTableOp(hashtables.HashTableUpdateDefault),
+	"hash-table-keys":            hashtables.HashTableOp(hashtables.HashTableKeys),
+	"hash-table-values":          hashtables.HashTableOp(hashtables.HashTableValues),
+	"hash-table->alist":          hashtables.HashTableOp(hashtables.HashTableToAlist),
+	"hash-table-walk":            hashtables.HashTableOp(hashtables.HashTableWalk),
+	"hash-table-count":           hashtables.HashTableOp(hashtables.HashTableCount),
+	"hash-table-size":            hashtables.HashTableOp(hashtables.HashTableSize),
+	"equal-hash":                 hashtables.HashTableOp(hashtables.EqualHash),
+	"string-hash":                hashtables.HashTableOp(hashtables.StringHash),
+}
+
+func init() {
+	for _, library := range Libraries() {
+		lib := libraries[library]
+		names := make([]string, 0, len(lib))
+		for name := range lib {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			types.NamePrimitive(name, lib[name])
+		}
 	}
 }
+
+// Register - adds builtin definition provided by another package
+// to the library, e.g. procedures that need the evaluator to call
+// other procedures
+func Register(library, name string, obj types.Object) {
+	lib, ok := libraries[library]
+	if !ok {
+		lib = make(map[string]types.Object)
+		libraries[library] = lib
+	}
+
+	lib[name] = obj
+	types.NamePrimitive(name, obj)
+}
+
+// Library - returns definitions exported by the builtin library,
+// reports false if there is no such library
+func Library(name string) (map[string]types.Object, bool) {
+	lib, ok := libraries[name]
+	if !ok {
+		return nil, false
+	}
+
+	defs := make(map[string]types.Object, len(lib))
+	for k, v := range lib {
+		defs[k] = v
+	}
+
+	return defs, true
+}
+
+// Libraries - returns names of the builtin libraries
+func Libraries() []string {
+	names := make([]string, 0, len(libraries))
+	for name := range libraries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// DefaultDefinitions - returns a symbol table with definitions
+// of all the builtin libraries
+func DefaultDefinitions() map[string]types.Object {
+	defs := make(map[string]types.Object)
+	for _, lib := range libraries {
+		for name, obj := range lib {
+			defs[name] = obj
+		}
+	}
+
+	return defs
+}
@@ -0,0 +1,229 @@
+package equivalence
+
+import (
+	"bytes"
+	"hash/fnv"
+	"math"
+	"reflect"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+)
+
+// EquivalenceOp - wrapper for builtin equivalence predicates
+type EquivalenceOp func(args ...types.Object) (types.Object, error)
+
+func (e EquivalenceOp) Call(args ...types.Object) (types.Object, error) {
+	return e(args...)
+}
+
+func (e EquivalenceOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// IsEqv - `eqv?` and `eq?` primitive
+func IsEqv(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	return types.Boolean(Eqv(args[0], args[1])), nil
+}
+
+// IsEqual - `equal?` primitive
+func IsEqual(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	return types.Boolean(Equal(args[0], args[1])), nil
+}
+
+// Eqv - reports whether the objects are the same: numbers of the
+// same exactness and value, atoms of the same value, otherwise
+// the same object
+func Eqv(a, b types.Object) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if x, ok := a.(*types.Number); ok {
+		y, ok := b.(*types.Number)
+		return ok && x.IsInt() == y.IsInt() && x.Value() == y.Value()
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	// builtin procedures are functions, which are
+	// the same when they point to the same code
+	if va.Kind() == reflect.Func {
+		return va.Pointer() == vb.Pointer()
+	}
+
+	if !va.Type().Comparable() {
+		return false
+	}
+
+	return a == b
+}
+
+// Equal - reports whether the objects are structurally equal:
+// pairs, vectors, bytevectors and records of the same type are
+// compared by their contents, the rest objects by Eqv. Circular
+// structures are equal if they unfold into the same ones
+func Equal(a, b types.Object) bool {
+	return equal(a, b, make(map[[2]types.Object]bool))
+}
+
+// equal - compares the objects, compared are the pairs of mutable
+// objects being compared already, which are assumed to be equal
+func equal(a, b types.Object, compared map[[2]types.Object]bool) bool {
+	switch x := a.(type) {
+	case *types.Pair:
+		y, ok := b.(*types.Pair)
+		return ok && (visit(x, y, compared) || equal(x.Car, y.Car, compared) && equal(x.Cdr, y.Cdr, compared))
+	case *types.Vector:
+		y, ok := b.(*types.Vector)
+		return ok && (visit(x, y, compared) || equalItems(x.Items(), y.Items(), compared))
+	case *types.Bytevector:
+		y, ok := b.(*types.Bytevector)
+		return ok && bytes.Equal(x.Bytes(), y.Bytes())
+	case *types.Record:
+		y, ok := b.(*types.Record)
+		return ok && x.Type == y.Type && (visit(x, y, compared) || equalItems(x.Fields, y.Fields, compared))
+	}
+
+	return Eqv(a, b)
+}
+
+// visit - reports whether the objects are compared already,
+// marking them as compared otherwise
+func visit(a, b types.Object, compared map[[2]types.Object]bool) bool {
+	key := [2]types.Object{a, b}
+	if compared[key] {
+		return true
+	}
+
+	compared[key] = true
+	return false
+}
+
+// equalItems - compares the sequences elementwise
+func equalItems(xs, ys []types.Object, compared map[[2]types.Object]bool) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+
+	for i := range xs {
+		if !equal(xs[i], ys[i], compared) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hashBudget - number of the nested objects hashed by EqualHash,
+// which keeps hashing of large and circular structures bounded
+const hashBudget = 64
+
+// EqualHash - hash of the object consistent with Equal:
+// objects which are equal have the same hash
+func EqualHash(obj types.Object) uint64 {
+	budget := hashBudget
+	return equalHash(obj, &budget)
+}
+
+// EqvHash - hash of the object consistent with Eqv
+func EqvHash(obj types.Object) uint64 {
+	switch obj.(type) {
+	case *types.Pair, *types.Vector, *types.Bytevector, *types.Record:
+		return identityHash(obj)
+	}
+
+	return EqualHash(obj)
+}
+
+// StringHash - hash of the string
+func StringHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// equalHash - hashes the object spending the budget on nested ones
+func equalHash(obj types.Object, budget *int) uint64 {
+	if *budget <= 0 {
+		return 0
+	}
+
+	*budget--
+	switch v := obj.(type) {
+	case nil:
+		return 0
+	case *types.Number:
+		if n, ok := v.Value().(int64); ok {
+			return mix(1, uint64(n))
+		}
+
+		f := v.Value().(float64)
+		if f == 0 { // -0.0 and 0.0 are the same
+			f = 0
+		}
+
+		return mix(2, math.Float64bits(f))
+	case types.String:
+		return mix(3, StringHash(string(v)))
+	case types.Symbol:
+		return mix(4, StringHash(string(v)))
+	case types.Keyword:
+		return mix(5, StringHash(string(v)))
+	case types.Boolean:
+		if v {
+			return mix(6, 1)
+		}
+
+		return mix(6, 0)
+	case *types.EmptyList:
+		return 7
+	case *types.Pair:
+		return mix(mix(8, equalHash(v.Car, budget)), equalHash(v.Cdr, budget))
+	case *types.Vector:
+		return itemsHash(9, v.Items(), budget)
+	case *types.Bytevector:
+		return mix(10, StringHash(string(v.Bytes())))
+	case *types.Record:
+		return itemsHash(mix(11, StringHash(v.Type.Name)), v.Fields, budget)
+	}
+
+	return identityHash(obj)
+}
+
+// itemsHash - combines hashes of the sequence items
+func itemsHash(h uint64, items []types.Object, budget *int) uint64 {
+	for _, item := range items {
+		h = mix(h, equalHash(item, budget))
+	}
+
+	return h
+}
+
+// identityHash - hash of the object compared by identity
+func identityHash(obj types.Object) uint64 {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Func, reflect.Map, reflect.Slice, reflect.Chan, reflect.UnsafePointer:
+		return mix(12, uint64(v.Pointer()))
+	}
+
+	return mix(13, StringHash(v.Type().String()))
+}
+
+// mix - combines two hashes
+func mix(h, v uint64) uint64 {
+	h ^= v + 0x9e3779b97f4a7c15 + (h << 6) + (h >> 2)
+	return h
+}
@@ -0,0 +1,526 @@
+package hashtables
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/equivalence"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// HashTableOp - wrapper for builtin hash table procedures
+type HashTableOp func(args ...types.Object) (types.Object, error)
+
+func (h HashTableOp) Call(args ...types.Object) (types.Object, error) {
+	return h(args...)
+}
+
+func (h HashTableOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// native equality and hash procedures, tables using them
+// compare and hash keys without calling the procedures
+var (
+	isEqv      = equivalence.EquivalenceOp(equivalence.IsEqv)
+	isEqual    = equivalence.EquivalenceOp(equivalence.IsEqual)
+	equalHash  = HashTableOp(EqualHash)
+	stringHash = HashTableOp(StringHash)
+)
+
+// MakeHashTable - `make-hash-table` primitive: (make-hash-table [equality [hash]])
+// creates table comparing keys by equal? by default. The hash procedure
+// defaults to the one consistent with eq?, eqv? and equal?, tables with
+// other equality procedures put all the keys into a single bucket,
+// unless the hash procedure is given
+func MakeHashTable(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 2); err != nil {
+		return nil, err
+	}
+
+	var equiv, hash types.Object = isEqual, nil
+	if len(args) > 0 {
+		equiv = args[0]
+	}
+
+	if len(args) > 1 {
+		hash = args[1]
+	}
+
+	for _, proc := range []types.Object{equiv, hash} {
+		if _, ok := proc.(types.Callable); proc != nil && !ok {
+			return nil, fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, proc)
+		}
+	}
+
+	ht := types.NewHashTable(equiv, hash)
+	switch {
+	case equivalence.Eqv(equiv, isEqual):
+		ht.Same, ht.HashOf = equivalence.Equal, native(equivalence.EqualHash)
+	case equivalence.Eqv(equiv, isEqv):
+		ht.Same, ht.HashOf = equivalence.Eqv, native(equivalence.EqvHash)
+	default:
+		ht.HashOf = native(func(types.Object) uint64 { return 0 })
+	}
+
+	switch {
+	case hash == nil:
+	case equivalence.Eqv(hash, equalHash):
+		ht.HashOf = native(equivalence.EqualHash)
+	case equivalence.Eqv(hash, stringHash):
+		ht.HashOf = func(obj types.Object) (uint64, error) {
+			s, err := str(obj)
+			return equivalence.StringHash(s), err
+		}
+	default:
+		ht.HashOf = nil
+	}
+
+	return ht, nil
+}
+
+// native - wraps hash function which never fails
+func native(hash func(types.Object) uint64) func(types.Object) (uint64, error) {
+	return func(obj types.Object) (uint64, error) {
+		return hash(obj), nil
+	}
+}
+
+// IsHashTable - `hash-table?` primitive
+func IsHashTable(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	_, ok := args[0].(*types.HashTable)
+	return types.Boolean(ok), nil
+}
+
+// HashTableRef - `hash-table-ref` primitive: (hash-table-ref table key [failure [success]])
+// returns value of the key, passed to success if it is given. Missing key
+// is an error, unless the failure thunk is given, its result is returned then
+func HashTableRef(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 4); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup(ht, args[1], func(_ uint64, e *types.Entry) (types.Object, error) {
+		switch {
+		case e == nil && len(args) > 2:
+			return &types.Apply{Proc: args[2]}, nil
+		case e == nil:
+			return nil, fmt.Errorf("%w: %v", errscm.ErrKeyNotFound, args[1])
+		case len(args) > 3:
+			return &types.Apply{Proc: args[3], Args: []types.Object{e.Value}}, nil
+		}
+
+		return e.Value, nil
+	})
+}
+
+// HashTableRefDefault - `hash-table-ref/default` primitive:
+// (hash-table-ref/default table key default)
+func HashTableRefDefault(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 3, 3); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup(ht, args[1], func(_ uint64, e *types.Entry) (types.Object, error) {
+		if e == nil {
+			return args[2], nil
+		}
+
+		return e.Value, nil
+	})
+}
+
+// HashTableSet - `hash-table-set!` primitive: (hash-table-set! table key value ...)
+// associates the keys with the values
+func HashTableSet(args ...types.Object) (types.Object, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, fmt.Errorf("%w: expected table followed by keys and values, got %d args", errscm.ErrUnexpectedNumberOfArguments, len(args))
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return set(ht, args[1:])
+}
+
+// set - associates the keys with the values one by one
+func set(ht *types.HashTable, kvs []types.Object) (types.Object, error) {
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+
+	return lookup(ht, kvs[0], func(h uint64, e *types.Entry) (types.Object, error) {
+		if e == nil {
+			ht.Put(h, kvs[0], kvs[1])
+		} else {
+			e.Value = kvs[1]
+		}
+
+		return set(ht, kvs[2:])
+	})
+}
+
+// HashTableDelete - `hash-table-delete!` primitive: (hash-table-delete! table key ...)
+// removes the keys, returns the number of the removed ones
+func HashTableDelete(args ...types.Object) (types.Object, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: expected at least 1 arg, got 0", errscm.ErrTooLittleArguments)
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return remove(ht, args[1:], 0)
+}
+
+// remove - removes the keys one by one counting the removed ones
+func remove(ht *types.HashTable, keys []types.Object, n int64) (types.Object, error) {
+	if len(keys) == 0 {
+		return types.NewNumber(n), nil
+	}
+
+	return lookup(ht, keys[0], func(h uint64, e *types.Entry) (types.Object, error) {
+		if e == nil {
+			return remove(ht, keys[1:], n)
+		}
+
+		ht.Remove(h, e)
+		return remove(ht, keys[1:], n+1)
+	})
+}
+
+// HashTableContains - `hash-table-contains?` primitive
+func HashTableContains(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup(ht, args[1], func(_ uint64, e *types.Entry) (types.Object, error) {
+		return types.Boolean(e != nil), nil
+	})
+}
+
+// HashTableUpdate - `hash-table-update!` primitive:
+// (hash-table-update! table key updater [failure [success]])
+// sets the key to the result of the updater applied to
+// the value of the key, obtained as by hash-table-ref
+func HashTableUpdate(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 3, 5); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	ref := append([]types.Object{ht, args[1]}, args[3:]...)
+	return update(ht, args[1], args[2], HashTableOp(HashTableRef), ref)
+}
+
+// HashTableUpdateDefault - `hash-table-update!/default` primitive:
+// (hash-table-update!/default table key updater default)
+func HashTableUpdateDefault(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 4, 4); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	ref := []types.Object{ht, args[1], args[3]}
+	return update(ht, args[1], args[2], HashTableOp(HashTableRefDefault), ref)
+}
+
+// update - applies the updater to the value obtained by
+// the ref procedure and sets the key to the result
+func update(ht *types.HashTable, key, updater types.Object, ref HashTableOp, args []types.Object) (types.Object, error) {
+	return &types.Apply{
+		Proc: ref,
+		Args: args,
+		Then: func(value types.Object) (types.Object, error) {
+			return &types.Apply{
+				Proc: updater,
+				Args: []types.Object{value},
+				Then: func(value types.Object) (types.Object, error) {
+					return set(ht, []types.Object{key, value})
+				},
+			}, nil
+		},
+	}, nil
+}
+
+// HashTableCount - `hash-table-count` primitive: (hash-table-count table)
+// returns the number of the entries, (hash-table-count pred table)
+// the number of the ones which key and value satisfy the predicate
+func HashTableCount(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 1 {
+		return types.NewNumber(int64(ht.Size())), nil
+	}
+
+	var n int64
+	return each(args[0], ht.Entries(), func(satisfies types.Object) {
+		if types.IsTrue(satisfies) {
+			n++
+		}
+	}, func() types.Object {
+		return types.NewNumber(n)
+	})
+}
+
+// HashTableSize - `hash-table-size` primitive
+func HashTableSize(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(int64(ht.Size())), nil
+}
+
+// HashTableKeys - `hash-table-keys` primitive
+func HashTableKeys(args ...types.Object) (types.Object, error) {
+	return entries(args, func(e *types.Entry) types.Object {
+		return e.Key
+	})
+}
+
+// HashTableValues - `hash-table-values` primitive
+func HashTableValues(args ...types.Object) (types.Object, error) {
+	return entries(args, func(e *types.Entry) types.Object {
+		return e.Value
+	})
+}
+
+// HashTableToAlist - `hash-table->alist` primitive
+func HashTableToAlist(args ...types.Object) (types.Object, error) {
+	return entries(args, func(e *types.Entry) types.Object {
+		return types.Cons(e.Key, e.Value)
+	})
+}
+
+// entries - list of the entries of the table converted by the function,
+// in the order of insertion
+func entries(args []types.Object, convert func(*types.Entry) types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	es := ht.Entries()
+	items := make([]types.Object, len(es))
+	for i, e := range es {
+		items[i] = convert(e)
+	}
+
+	return types.List(items...), nil
+}
+
+// HashTableWalk - `hash-table-walk` primitive: (hash-table-walk table proc)
+// applies the procedure to the key and value of every entry
+func HashTableWalk(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	ht, err := table(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return each(args[1], ht.Entries(), func(types.Object) {}, func() types.Object {
+		return nil
+	})
+}
+
+// each - applies the procedure to the keys and values of the entries
+// one by one, passing the results to collect
+func each(proc types.Object, es []*types.Entry, collect func(types.Object), finish func() types.Object) (types.Object, error) {
+	if _, ok := proc.(types.Callable); !ok {
+		return nil, fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, proc)
+	}
+
+	var step func(i int) (types.Object, error)
+	step = func(i int) (types.Object, error) {
+		if i == len(es) {
+			return finish(), nil
+		}
+
+		return &types.Apply{
+			Proc: proc,
+			Args: []types.Object{es[i].Key, es[i].Value},
+			Then: func(result types.Object) (types.Object, error) {
+				collect(result)
+				return step(i + 1)
+			},
+		}, nil
+	}
+
+	return step(0)
+}
+
+// EqualHash - `equal-hash` primitive: (equal-hash obj [bound])
+// hash consistent with equal?
+func EqualHash(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	return bounded(equivalence.EqualHash(args[0]), args[1:])
+}
+
+// StringHash - `string-hash` primitive: (string-hash string [bound])
+func StringHash(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	s, err := str(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return bounded(equivalence.StringHash(s), args[1:])
+}
+
+// bounded - converts hash into a non-negative integer
+// less than the optional bound
+func bounded(h uint64, args []types.Object) (types.Object, error) {
+	n := int64(h >> 2)
+	if len(args) == 0 {
+		return types.NewNumber(n), nil
+	}
+
+	bound, err := check.Index(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if bound == 0 {
+		return nil, fmt.Errorf("%w: hash bound must be positive", errscm.ErrIndexOutOfRange)
+	}
+
+	return types.NewNumber(n % int64(bound)), nil
+}
+
+// lookup - finds the entry of the key, then continues with the hash of
+// the key and the entry, which is nil for missing keys. Native hash and
+// equality procedures are called right away, the rest ones are applied
+// by the evaluator through the chain of requests
+func lookup(ht *types.HashTable, key types.Object, then func(uint64, *types.Entry) (types.Object, error)) (types.Object, error) {
+	if ht.HashOf != nil {
+		h, err := ht.HashOf(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return scan(ht, key, h, 0, then)
+	}
+
+	return &types.Apply{
+		Proc: ht.Hash,
+		Args: []types.Object{key},
+		Then: func(value types.Object) (types.Object, error) {
+			h, err := check.Index(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return scan(ht, key, uint64(h), 0, then)
+		},
+	}, nil
+}
+
+// scan - compares the key with the keys of the bucket starting from i
+func scan(ht *types.HashTable, key types.Object, h uint64, i int, then func(uint64, *types.Entry) (types.Object, error)) (types.Object, error) {
+	bucket := ht.Bucket(h)
+	if ht.Same != nil {
+		for _, e := range bucket {
+			if ht.Same(key, e.Key) {
+				return then(h, e)
+			}
+		}
+
+		return then(h, nil)
+	}
+
+	if i >= len(bucket) {
+		return then(h, nil)
+	}
+
+	e := bucket[i]
+	return &types.Apply{
+		Proc: ht.Equiv,
+		Args: []types.Object{key, e.Key},
+		Then: func(same types.Object) (types.Object, error) {
+			if types.IsTrue(same) {
+				return then(h, e)
+			}
+
+			return scan(ht, key, h, i+1, then)
+		},
+	}, nil
+}
+
+// table - asserts that the object is a hash table
+func table(obj types.Object) (*types.HashTable, error) {
+	ht, ok := obj.(*types.HashTable)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected hash table, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	return ht, nil
+}
+
+// str - asserts that the object is a string
+func str(obj types.Object) (string, error) {
+	s, ok := obj.(types.String)
+	if !ok {
+		return "", fmt.Errorf("%w: expected string, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	return string(s), nil
+}
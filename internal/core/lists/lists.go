@@ -0,0 +1,67 @@
+package lists
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// ListOp - wrapper for builtin pair and list procedures
+type ListOp func(args ...types.Object) (types.Object, error)
+
+func (l ListOp) Call(args ...types.Object) (types.Object, error) {
+	return l(args...)
+}
+
+func (l ListOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// Cons - `cons` primitive
+func Cons(args ...types.Object) (types.Object, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("%w: expected 2 args, got %d", errscm.ErrUnexpectedNumberOfArguments, len(args))
+	}
+
+	return types.Cons(args[0], args[1]), nil
+}
+
+// Car - `car` primitive
+func Car(args ...types.Object) (types.Object, error) {
+	p, err := pairArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Car, nil
+}
+
+// Cdr - `cdr` primitive
+func Cdr(args ...types.Object) (types.Object, error) {
+	p, err := pairArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Cdr, nil
+}
+
+// List - `list` primitive
+func List(args ...types.Object) (types.Object, error) {
+	return types.List(args...), nil
+}
+
+// pairArg - checks that the only argument is a pair
+func pairArg(args []types.Object) (*types.Pair, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: expected 1 arg, got %d", errscm.ErrUnexpectedNumberOfArguments, len(args))
+	}
+
+	p, ok := args[0].(*types.Pair)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected pair, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return p, nil
+}
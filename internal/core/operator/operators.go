@@ -31,3 +31,16 @@ func Run[T int64 | float64 | complex128](op Operator, a T, b T) (result T) {
 func Neg[T int64 | float64 | complex128](a T) T {
 	return -a
 }
+
+// Compare - compares given generic numbers,
+// returns -1 if a < b, 0 if a == b and 1 if a > b
+func Compare[T int64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,105 @@
+package procedures
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// ProcedureOp - wrapper for builtin procedures operating on procedures
+type ProcedureOp func(args ...types.Object) (types.Object, error)
+
+func (p ProcedureOp) Call(args ...types.Object) (types.Object, error) {
+	return p(args...)
+}
+
+func (p ProcedureOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// IsProcedure - `procedure?` primitive
+func IsProcedure(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	_, ok := args[0].(types.Callable)
+	return types.Boolean(ok), nil
+}
+
+// Values - `values` primitive
+func Values(args ...types.Object) (types.Object, error) {
+	objs := make([]types.Object, len(args))
+	copy(objs, args)
+	return types.MultipleValues(objs...), nil
+}
+
+// ProcedureArity - `procedure-arity` primitive, describes argument
+// counts as a (min . max) pair, where max is #f for procedures
+// without the upper limit. Procedures with several clauses, such
+// as case-lambda, are described with a list of pairs per clause.
+// Arity of builtin procedures is unknown and reported as (0 . #f)
+func ProcedureArity(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	if _, ok := args[0].(types.Callable); !ok {
+		return nil, fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	proc, ok := args[0].(types.Introspectable)
+	if !ok {
+		return arityPair(types.Arity{Min: 0, Max: -1}), nil
+	}
+
+	arities := proc.Arities()
+	if len(arities) == 1 {
+		return arityPair(arities[0]), nil
+	}
+
+	pairs := make([]types.Object, len(arities))
+	for i, a := range arities {
+		pairs[i] = arityPair(a)
+	}
+
+	return types.List(pairs...), nil
+}
+
+// arityPair - converts arity into (min . max) pair
+func arityPair(a types.Arity) types.Object {
+	var max types.Object = types.Boolean(false)
+	if a.Max >= 0 {
+		max = types.NewNumber(int64(a.Max))
+	}
+
+	return types.Cons(types.NewNumber(int64(a.Min)), max)
+}
+
+// MakeParameter - `make-parameter` primitive: (make-parameter value [converter])
+// creates parameter object, the converter is applied to the initial
+// value and to the values the parameter is bound to by parameterize
+func MakeParameter(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 1 {
+		return types.NewParameter(args[0], nil), nil
+	}
+
+	converter := args[1]
+	if _, ok := converter.(types.Callable); !ok {
+		return nil, fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, converter)
+	}
+
+	return &types.Apply{
+		Proc: converter,
+		Args: []types.Object{args[0]},
+		Then: func(value types.Object) (types.Object, error) {
+			return types.NewParameter(value, converter), nil
+		},
+	}, nil
+}
@@ -0,0 +1,129 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// ProcessOp - wrapper for builtin procedures of the process context
+type ProcessOp func(args ...types.Object) (types.Object, error)
+
+func (p ProcessOp) Call(args ...types.Object) (types.Object, error) {
+	return p(args...)
+}
+
+func (p ProcessOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// commandLine - command line of the program,
+// the first element names the program
+var commandLine = os.Args
+
+// SetCommandLine - sets the command line returned by `command-line`,
+// the first argument is expected to name the program
+func SetCommandLine(args ...string) {
+	commandLine = args
+}
+
+// CommandLine - `command-line` primitive: list of the
+// command line arguments as strings
+func CommandLine(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	items := make([]types.Object, len(commandLine))
+	for i, arg := range commandLine {
+		items[i] = types.String(arg)
+	}
+
+	return types.List(items...), nil
+}
+
+// Exit - `exit` primitive: exits the program with the status after
+// running the outstanding dynamic-wind after thunks
+func Exit(args ...types.Object) (types.Object, error) {
+	return exit(args, false)
+}
+
+// EmergencyExit - `emergency-exit` primitive: exits the program with
+// the status without running the outstanding after thunks
+func EmergencyExit(args ...types.Object) (types.Object, error) {
+	return exit(args, true)
+}
+
+// exit - converts the optional argument into the exit status: none
+// or #t stand for success, #f for failure, integers from 0 to 255
+// are the status
+func exit(args []types.Object, emergency bool) (types.Object, error) {
+	if err := check.Arity(args, 0, 1); err != nil {
+		return nil, err
+	}
+
+	code := 0
+	if len(args) == 1 {
+		switch obj := args[0].(type) {
+		case types.Boolean:
+			if !obj {
+				code = 1
+			}
+		case *types.Number:
+			if !obj.IsInt() {
+				return nil, fmt.Errorf("%w: expected exact integer or boolean, got %v", errscm.ErrUnexpectedType, obj)
+			}
+
+			if obj.Int() < 0 || obj.Int() > 255 {
+				return nil, fmt.Errorf("%w: exit status %v is out of range 0..255", errscm.ErrUnexpectedType, obj)
+			}
+
+			code = int(obj.Int())
+		default:
+			return nil, fmt.Errorf("%w: expected exact integer or boolean, got %v", errscm.ErrUnexpectedType, obj)
+		}
+	}
+
+	return nil, &errscm.ExitError{Code: code, Emergency: emergency}
+}
+
+// GetEnvironmentVariable - `get-environment-variable` primitive:
+// value of the environment variable, #f if it is not set
+func GetEnvironmentVariable(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	name, ok := args[0].(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected string, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	value, ok := os.LookupEnv(string(name))
+	if !ok {
+		return types.Boolean(false), nil
+	}
+
+	return types.String(value), nil
+}
+
+// GetEnvironmentVariables - `get-environment-variables` primitive:
+// association list of the environment variables and their values
+func GetEnvironmentVariables(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	env := os.Environ()
+	items := make([]types.Object, 0, len(env))
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		items = append(items, types.Cons(types.String(name), types.String(value)))
+	}
+
+	return types.List(items...), nil
+}
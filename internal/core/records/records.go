@@ -0,0 +1,93 @@
+package records
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// RecordOp - wrapper for the procedures generated by define-record-type
+type RecordOp func(args ...types.Object) (types.Object, error)
+
+func (r RecordOp) Call(args ...types.Object) (types.Object, error) {
+	return r(args...)
+}
+
+func (r RecordOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// Constructor - creates record constructor accepting values of the
+// fields with the given indices, the rest fields are left unspecified
+func Constructor(rt *types.RecordType, fields []int) RecordOp {
+	return func(args ...types.Object) (types.Object, error) {
+		if err := check.Arity(args, len(fields), len(fields)); err != nil {
+			return nil, err
+		}
+
+		values := make([]types.Object, len(rt.Fields))
+		for i, field := range fields {
+			values[field] = args[i]
+		}
+
+		return types.NewRecord(rt, values), nil
+	}
+}
+
+// Predicate - creates predicate reporting whether
+// its argument is a record of the type
+func Predicate(rt *types.RecordType) RecordOp {
+	return func(args ...types.Object) (types.Object, error) {
+		if err := check.Arity(args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		r, ok := args[0].(*types.Record)
+		return types.Boolean(ok && r.Type == rt), nil
+	}
+}
+
+// Accessor - creates procedure returning the field of the record
+func Accessor(rt *types.RecordType, field int, name string) RecordOp {
+	return func(args ...types.Object) (types.Object, error) {
+		if err := check.Arity(args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		r, err := record(rt, args[0], name)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.Fields[field], nil
+	}
+}
+
+// Modifier - creates procedure setting the field of the record
+func Modifier(rt *types.RecordType, field int, name string) RecordOp {
+	return func(args ...types.Object) (types.Object, error) {
+		if err := check.Arity(args, 2, 2); err != nil {
+			return nil, err
+		}
+
+		r, err := record(rt, args[0], name)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Fields[field] = args[1]
+		return nil, nil
+	}
+}
+
+// record - asserts that the object is a record of the type
+func record(rt *types.RecordType, obj types.Object, name string) (*types.Record, error) {
+	r, ok := obj.(*types.Record)
+	if !ok || r.Type != rt {
+		return nil, fmt.Errorf("%w: %s expects %s record, got %v", errscm.ErrUnexpectedType, name, rt.Name, obj)
+	}
+
+	return r, nil
+}
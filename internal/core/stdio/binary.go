@@ -0,0 +1,140 @@
+package stdio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Vallghall/gopherscm/internal/core/bytevectors"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// OpenInputBytevector - `open-input-bytevector` primitive: creates
+// port reading the bytes of the bytevector
+func OpenInputBytevector(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	bv, ok := args[0].(*types.Bytevector)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected bytevector, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return types.NewInputPort(bytes.NewReader(bv.Bytes())), nil
+}
+
+// ReadU8 - `read-u8` primitive: reads the next byte from the port,
+// returns the eof object if there are none left
+func ReadU8(args ...types.Object) (types.Object, error) {
+	return nextByte(args, (*bufio.Reader).ReadByte)
+}
+
+// PeekU8 - `peek-u8` primitive: returns the next byte of the port
+// without consuming it, the eof object if there are none left
+func PeekU8(args ...types.Object) (types.Object, error) {
+	return nextByte(args, func(r *bufio.Reader) (byte, error) {
+		bs, err := r.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+
+		return bs[0], nil
+	})
+}
+
+// nextByte - reads the byte from the only argument port
+func nextByte(args []types.Object, read func(*bufio.Reader) (byte, error)) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	port, ok := args[0].(*types.InputPort)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected input port, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	u8, err := read(port.Reader())
+	if errors.Is(err, io.EOF) {
+		return types.EOF, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(int64(u8)), nil
+}
+
+// OpenOutputBytevector - `open-output-bytevector` primitive:
+// creates port accumulating the output into a bytevector
+func OpenOutputBytevector(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	return types.NewOutputPort(new(bytes.Buffer)), nil
+}
+
+// WriteU8 - `write-u8` primitive: writes the byte to the port
+// or to the current output port
+func WriteU8(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	u8, err := bytevectors.Byte(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := output(args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = port.Writer().Write([]byte{u8})
+	return nil, err
+}
+
+// GetOutputBytevector - `get-output-bytevector` primitive:
+// returns the bytes accumulated by the bytevector port
+func GetOutputBytevector(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	port, err := OutputPort(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	buf, ok := port.(*types.OutputPort).Writer().(*bytes.Buffer)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected bytevector port, got %v", errscm.ErrUnexpectedType, port)
+	}
+
+	return types.NewBytevector(bytes.Clone(buf.Bytes())), nil
+}
+
+// EOFObject - `eof-object` primitive
+func EOFObject(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	return types.EOF, nil
+}
+
+// IsEOFObject - `eof-object?` primitive
+func IsEOFObject(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	return types.Boolean(args[0] == types.EOF), nil
+}
@@ -2,6 +2,10 @@ package stdio
 
 import (
 	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
 	"github.com/Vallghall/gopherscm/internal/errscm"
 
 	"github.com/Vallghall/gopherscm/internal/core/types"
@@ -19,30 +23,165 @@ func (p IOHandler) Value() any {
 	return "PrimitiveOperation"
 }
 
-// Display - prints given args to stdout
+// CurrentOutputPort - `current-output-port` parameter,
+// the port output procedures write to by default
+var CurrentOutputPort = types.NewParameter(types.NewOutputPort(os.Stdout), IOHandler(OutputPort))
+
+// Display - prints given arg to the port or to the current output port
 func Display(args ...types.Object) (types.Object, error) {
-	if len(args) != 1 {
-		return nil, fmt.Errorf("%w: expected 1 arg, got %d", errscm.ErrUnexpectedNumberOfArguments, len(args))
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	port, err := output(args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprint(port.Writer(), args[0])
+	return nil, nil
+}
+
+// Write - prints external representation of the given arg, the way
+// it is read back, to the port or to the current output port
+func Write(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	port, err := output(args[1:])
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Print(args[0])
+	fmt.Fprint(port.Writer(), Written(args[0]))
 	return nil, nil
 }
 
-// NewLine - prints new line character to stdout
-// args are to match the IOHandler alias, they're not used
+// Written - external representation of the object, see types.Write
+func Written(obj types.Object) string {
+	return types.Write(obj)
+}
+
+// NewLine - prints new line character to the port
+// or to the current output port
 func NewLine(args ...types.Object) (types.Object, error) {
-	fmt.Println()
+	if err := check.Arity(args, 0, 1); err != nil {
+		return nil, err
+	}
+
+	port, err := output(args)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(port.Writer())
 	return nil, nil
 }
 
-// Displayln - prints given args to stdout and adds
-// a new line character at the end
+// FlushOutputPort - writes out the output buffered
+// by the port or by the current output port
+func FlushOutputPort(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 1); err != nil {
+		return nil, err
+	}
+
+	port, err := output(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, flush(port)
+}
+
+// Flush - writes out the output buffered by the current
+// output port, done before the program exits
+func Flush() error {
+	return flush(CurrentOutputPort.Get().(*types.OutputPort))
+}
+
+// flush - flushes writers buffering the output, the
+// others write it out immediately
+func flush(port *types.OutputPort) error {
+	if f, ok := port.Writer().(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// Displayln - prints given arg to the port or to the current
+// output port and adds a new line character at the end
 func Displayln(args ...types.Object) (types.Object, error) {
-	if len(args) != 1 {
-		return nil, fmt.Errorf("%w: expected 1 arg, got %d", errscm.ErrUnexpectedNumberOfArguments, len(args))
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	port, err := output(args[1:])
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Println(args[0])
+	fmt.Fprintln(port.Writer(), args[0])
 	return nil, nil
 }
+
+// OpenOutputString - `open-output-string` primitive: creates
+// port accumulating the output into a string
+func OpenOutputString(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return nil, err
+	}
+
+	return types.NewOutputPort(new(strings.Builder)), nil
+}
+
+// GetOutputString - `get-output-string` primitive: returns
+// the output accumulated by the string port
+func GetOutputString(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	port, err := OutputPort(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	sb, ok := port.(*types.OutputPort).Writer().(*strings.Builder)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected string port, got %v", errscm.ErrUnexpectedType, port)
+	}
+
+	return types.String(sb.String()), nil
+}
+
+// OutputPort - asserts that the only argument is an output port,
+// converter of the `current-output-port` parameter
+func OutputPort(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	port, ok := args[0].(*types.OutputPort)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected output port, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return port, nil
+}
+
+// output - returns the port given as the optional argument,
+// or the current output port
+func output(args []types.Object) (*types.OutputPort, error) {
+	if len(args) == 0 {
+		return CurrentOutputPort.Get().(*types.OutputPort), nil
+	}
+
+	port, err := OutputPort(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return port.(*types.OutputPort), nil
+}
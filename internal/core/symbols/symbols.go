@@ -0,0 +1,85 @@
+package symbols
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// SymbolOp - wrapper for builtin symbol procedures
+type SymbolOp func(args ...types.Object) (types.Object, error)
+
+func (s SymbolOp) Call(args ...types.Object) (types.Object, error) {
+	return s(args...)
+}
+
+func (s SymbolOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// IsSymbol - `symbol?` primitive
+func IsSymbol(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	_, ok := args[0].(types.Symbol)
+	return types.Boolean(ok), nil
+}
+
+// SymbolToString - `symbol->string` primitive
+func SymbolToString(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	s, err := symbol(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.String(s), nil
+}
+
+// StringToSymbol - `string->symbol` primitive
+func StringToSymbol(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	s, ok := args[0].(types.String)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected string, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return types.Symbol(s), nil
+}
+
+// SymbolAppend - `symbol-append` primitive: creates symbol
+// out of the names of the given symbols
+func SymbolAppend(args ...types.Object) (types.Object, error) {
+	var sb strings.Builder
+	for _, arg := range args {
+		s, err := symbol(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		sb.WriteString(s)
+	}
+
+	return types.Symbol(sb.String()), nil
+}
+
+// symbol - asserts that the object is a symbol
+func symbol(obj types.Object) (string, error) {
+	s, ok := obj.(types.Symbol)
+	if !ok {
+		return "", fmt.Errorf("%w: expected symbol, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	return string(s), nil
+}
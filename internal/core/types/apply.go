@@ -0,0 +1,21 @@
+package types
+
+// Apply - request returned by builtins to make the evaluator
+// apply the procedure to the arguments instead of calling it
+// from Go, so that the call takes part in the evaluator's
+// continuation. The result is passed to Then, which returns
+// the builtin's result or yet another request. Requests without
+// Then are calls in tail position.
+//
+// Then may be invoked several times when the continuation
+// is re-entered, so it must not mutate state shared between calls
+type Apply struct {
+	Proc Object
+	Args []Object
+	Then func(Object) (Object, error)
+}
+
+// Value - Object implementation
+func (a *Apply) Value() any {
+	return a.Proc
+}
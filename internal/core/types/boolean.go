@@ -0,0 +1,24 @@
+package types
+
+// Boolean - wrapper for #t and #f
+type Boolean bool
+
+// Value - Object implementation
+func (b Boolean) Value() any {
+	return bool(b)
+}
+
+func (b Boolean) String() string {
+	if b {
+		return "#t"
+	}
+
+	return "#f"
+}
+
+// IsTrue - reports whether the object counts as true
+// in conditional expressions: everything except #f does
+func IsTrue(obj Object) bool {
+	b, ok := obj.(Boolean)
+	return !ok || bool(b)
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// Bytevector - mutable fixed-length sequence of bytes,
+// also serves as a buffer for binary data
+type Bytevector struct {
+	bytes []byte
+}
+
+// NewBytevector - Bytevector constructor from the given bytes
+func NewBytevector(bs []byte) *Bytevector {
+	if bs == nil {
+		bs = make([]byte, 0)
+	}
+
+	return &Bytevector{
+		bytes: bs,
+	}
+}
+
+// Value - Object implementation
+func (b *Bytevector) Value() any {
+	return b.bytes
+}
+
+// Bytes - returns underlying byte slice
+func (b *Bytevector) Bytes() []byte {
+	return b.bytes
+}
+
+// Len - returns bytevector length
+func (b *Bytevector) Len() int {
+	return len(b.bytes)
+}
+
+// Ref - returns byte at index k
+func (b *Bytevector) Ref(k int) (byte, error) {
+	if k < 0 || k >= len(b.bytes) {
+		return 0, fmt.Errorf("%w: index %d, bytevector length %d", errscm.ErrIndexOutOfRange, k, len(b.bytes))
+	}
+
+	return b.bytes[k], nil
+}
+
+// Set - replaces byte at index k
+func (b *Bytevector) Set(k int, u8 byte) error {
+	if k < 0 || k >= len(b.bytes) {
+		return fmt.Errorf("%w: index %d, bytevector length %d", errscm.ErrIndexOutOfRange, k, len(b.bytes))
+	}
+
+	b.bytes[k] = u8
+	return nil
+}
+
+func (b *Bytevector) String() string {
+	var sb strings.Builder
+	sb.WriteString("#u8(")
+	for i, u8 := range b.bytes {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprint(&sb, u8)
+	}
+
+	sb.WriteByte(')')
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorObject - condition raised by `error` and by failing
+// builtins, it is a Go error as well, wrapping the one it
+// was made of, if any
+type ErrorObject struct {
+	Message   string
+	Irritants []Object
+	// Err - Go error the condition was made of
+	Err error
+}
+
+// ErrorOf - returns the condition the error is made of,
+// wrapping Go errors into new ones
+func ErrorOf(err error) *ErrorObject {
+	var obj *ErrorObject
+	if errors.As(err, &obj) {
+		return obj
+	}
+
+	return &ErrorObject{Message: err.Error(), Err: err}
+}
+
+// Value - Object implementation
+func (e *ErrorObject) Value() any {
+	return e.Message
+}
+
+func (e *ErrorObject) String() string {
+	return fmt.Sprintf("#<error %s>", e.Error())
+}
+
+// Error - error interface implementation
+func (e *ErrorObject) Error() string {
+	if len(e.Irritants) == 0 {
+		return e.Message
+	}
+
+	irritants := make([]string, len(e.Irritants))
+	for i, obj := range e.Irritants {
+		irritants[i] = fmt.Sprint(obj)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(irritants, " "))
+}
+
+// Unwrap - unwrap interface implementation
+func (e *ErrorObject) Unwrap() error {
+	return e.Err
+}
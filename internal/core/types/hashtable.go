@@ -0,0 +1,102 @@
+package types
+
+import "fmt"
+
+// Entry - association of the hash table
+type Entry struct {
+	Key     Object
+	Value   Object
+	deleted bool
+}
+
+// HashTable - mutable mapping of keys to values. Keys are compared
+// by the equality procedure and grouped by the value of the hash
+// procedure, the entries are kept in the order of insertion
+type HashTable struct {
+	// Equiv and Hash - equality and hash procedures of the table
+	Equiv Object
+	Hash  Object
+	// Same and HashOf - native implementations of the procedures,
+	// nil when the procedures have to be applied
+	Same   func(a, b Object) bool
+	HashOf func(obj Object) (uint64, error)
+
+	buckets map[uint64][]*Entry
+	entries []*Entry
+	size    int
+}
+
+// NewHashTable - HashTable constructor
+func NewHashTable(equiv, hash Object) *HashTable {
+	return &HashTable{
+		Equiv:   equiv,
+		Hash:    hash,
+		buckets: make(map[uint64][]*Entry),
+		entries: make([]*Entry, 0),
+	}
+}
+
+// Value - Object implementation
+func (ht *HashTable) Value() any {
+	return ht.buckets
+}
+
+func (ht *HashTable) String() string {
+	return fmt.Sprintf("#<hash-table %d>", ht.size)
+}
+
+// Bucket - entries with keys of the given hash
+func (ht *HashTable) Bucket(h uint64) []*Entry {
+	return ht.buckets[h]
+}
+
+// Put - adds new entry with the key of the given hash
+func (ht *HashTable) Put(h uint64, key, value Object) {
+	e := &Entry{Key: key, Value: value}
+	ht.buckets[h] = append(ht.buckets[h], e)
+	ht.entries = append(ht.entries, e)
+	ht.size++
+}
+
+// Remove - removes the entry with the key of the given hash
+func (ht *HashTable) Remove(h uint64, e *Entry) {
+	bucket := ht.buckets[h]
+	for i, be := range bucket {
+		if be != e {
+			continue
+		}
+
+		bucket = append(bucket[:i:i], bucket[i+1:]...)
+		if len(bucket) == 0 {
+			delete(ht.buckets, h)
+		} else {
+			ht.buckets[h] = bucket
+		}
+
+		e.deleted = true
+		ht.size--
+		break
+	}
+
+	// deleted entries are dropped once they outnumber the live ones
+	if 2*ht.size < len(ht.entries) {
+		ht.entries = ht.Entries()
+	}
+}
+
+// Entries - snapshot of the entries in the order of insertion
+func (ht *HashTable) Entries() []*Entry {
+	entries := make([]*Entry, 0, ht.size)
+	for _, e := range ht.entries {
+		if !e.deleted {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+// Size - number of the entries
+func (ht *HashTable) Size() int {
+	return ht.size
+}
@@ -0,0 +1,14 @@
+package types
+
+// Keyword - self-evaluating keyword object written
+// as `#:name` or `name:`, used for named arguments
+type Keyword string
+
+// Value - Object implementation
+func (k Keyword) Value() any {
+	return k
+}
+
+func (k Keyword) String() string {
+	return "#:" + string(k)
+}
@@ -55,6 +55,11 @@ func NumberFrom(obj any) *Number {
 	}
 }
 
+// IsInt - reports whether the number is an integer
+func (n *Number) IsInt() bool {
+	return n.t == Int
+}
+
 func (n *Number) Int() int64 {
 	return n.value.(int64)
 }
@@ -82,6 +87,30 @@ func (n *Number) ApplyOperation(op operator.Operator, o Object) (obj *Number, er
 	return
 }
 
+// Compare - compares numbers considering underlying types,
+// returns -1 if n < o, 0 if n == o and 1 if n > o
+func (n *Number) Compare(o Object) (int, error) {
+	num, ok := o.(*Number)
+	if !ok {
+		return 0, errscm.ErrNaN
+	}
+
+	if n.t == Int && num.t == Int {
+		return operator.Compare(n.Int(), num.Int()), nil
+	}
+
+	return operator.Compare(n.toFloat(), num.toFloat()), nil
+}
+
+// toFloat - converts number to float64 regardless of its type
+func (n *Number) toFloat() float64 {
+	if n.t == Int {
+		return float64(n.Int())
+	}
+
+	return n.Float()
+}
+
 // ApplyUnary - applies unary operator considering underlying types
 // Right now it is implemented for negation only
 func (n *Number) ApplyUnary() (obj Object, err error) {
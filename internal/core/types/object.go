@@ -15,3 +15,20 @@ type CallableObject interface {
 	Callable
 	Object
 }
+
+// Arity - range of argument counts accepted by a procedure,
+// Max is negative for procedures without the upper limit
+type Arity struct {
+	Min int
+	Max int
+}
+
+// Accepts - reports whether the procedure accepts n arguments
+func (a Arity) Accepts(n int) bool {
+	return n >= a.Min && (a.Max < 0 || n <= a.Max)
+}
+
+// Introspectable - procedure that reports argument counts it accepts
+type Introspectable interface {
+	Arities() []Arity
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// EmptyList - type of the empty list object '()
+type EmptyList struct{}
+
+// Null - the only instance of the empty list
+var Null = &EmptyList{}
+
+// Value - Object implementation
+func (e *EmptyList) Value() any {
+	return nil
+}
+
+func (e *EmptyList) String() string {
+	return "()"
+}
+
+// Pair - cons cell, the building block of lists
+type Pair struct {
+	Car Object
+	Cdr Object
+}
+
+// Cons - Pair constructor
+func Cons(car, cdr Object) *Pair {
+	return &Pair{
+		Car: car,
+		Cdr: cdr,
+	}
+}
+
+// Value - Object implementation
+func (p *Pair) Value() any {
+	return p
+}
+
+func (p *Pair) String() string {
+	return Display(p)
+}
+
+// List - builds a proper list out of the given objects
+func List(objs ...Object) Object {
+	var list Object = Null
+	for i := len(objs) - 1; i >= 0; i-- {
+		list = Cons(objs[i], list)
+	}
+
+	return list
+}
+
+// ListToSlice - collects elements of a proper list into a slice
+func ListToSlice(obj Object) ([]Object, error) {
+	objs := make([]Object, 0)
+	for {
+		switch p := obj.(type) {
+		case *EmptyList:
+			return objs, nil
+		case *Pair:
+			objs = append(objs, p.Car)
+			obj = p.Cdr
+		default:
+			return nil, fmt.Errorf("%w: expected proper list", errscm.ErrUnexpectedType)
+		}
+	}
+}
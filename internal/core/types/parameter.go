@@ -0,0 +1,50 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// Parameter - parameter object: procedure returning its value,
+// which is dynamically rebound by parameterize
+type Parameter struct {
+	value Object
+	// Converter - procedure applied to the values the parameter
+	// is bound to, nil for parameters without one
+	Converter Object
+}
+
+// NewParameter - creates parameter object with the value,
+// which is already converted
+func NewParameter(value, converter Object) *Parameter {
+	return &Parameter{value: value, Converter: converter}
+}
+
+// Get - returns current value of the parameter
+func (p *Parameter) Get() Object {
+	return p.value
+}
+
+// Set - rebinds the parameter to the value
+func (p *Parameter) Set(value Object) {
+	p.value = value
+}
+
+// Call - Callable implementation
+func (p *Parameter) Call(args ...Object) (Object, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("%w: expected 0 args, got %d", errscm.ErrUnexpectedNumberOfArguments, len(args))
+	}
+
+	return p.value, nil
+}
+
+// Value - Object implementation
+func (p *Parameter) Value() any {
+	return p.value
+}
+
+func (p *Parameter) String() string {
+	return "#<parameter>"
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"bufio"
+	"io"
+)
+
+// OutputPort - textual output port writing to the writer
+type OutputPort struct {
+	w io.Writer
+}
+
+// NewOutputPort - creates output port of the writer
+func NewOutputPort(w io.Writer) *OutputPort {
+	return &OutputPort{w: w}
+}
+
+// Writer - returns the writer of the port
+func (p *OutputPort) Writer() io.Writer {
+	return p.w
+}
+
+// Value - Object implementation
+func (p *OutputPort) Value() any {
+	return p.w
+}
+
+func (p *OutputPort) String() string {
+	return "#<output-port>"
+}
+
+// InputPort - binary input port reading bytes from the reader
+type InputPort struct {
+	r *bufio.Reader
+}
+
+// NewInputPort - creates input port of the reader
+func NewInputPort(r io.Reader) *InputPort {
+	return &InputPort{r: bufio.NewReader(r)}
+}
+
+// Reader - returns the buffered reader of the port
+func (p *InputPort) Reader() *bufio.Reader {
+	return p.r
+}
+
+// Value - Object implementation
+func (p *InputPort) Value() any {
+	return p.r
+}
+
+func (p *InputPort) String() string {
+	return "#<input-port>"
+}
+
+// EOFObject - object returned by input procedures
+// when the port has no more data
+type EOFObject struct{}
+
+// EOF - the only end of file object
+var EOF = &EOFObject{}
+
+// Value - Object implementation
+func (e *EOFObject) Value() any {
+	return nil
+}
+
+func (e *EOFObject) String() string {
+	return "#<eof>"
+}
@@ -0,0 +1,32 @@
+package types
+
+import "reflect"
+
+// primitives - names of the builtin procedures keyed by their
+// code pointers, as Go funcs can not be compared
+var primitives = make(map[uintptr]string)
+
+// NamePrimitive - records the name the builtin procedure is bound by,
+// the first one is kept for procedures bound by several names
+func NamePrimitive(name string, obj Object) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Func {
+		return
+	}
+
+	if _, ok := primitives[v.Pointer()]; !ok {
+		primitives[v.Pointer()] = name
+	}
+}
+
+// PrimitiveName - returns the name of the builtin procedure,
+// reports false for other objects
+func PrimitiveName(obj Object) (string, bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Func {
+		return "", false
+	}
+
+	name, ok := primitives[v.Pointer()]
+	return name, ok
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// escapes - replacer of the characters escaped in written strings
+var escapes = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+
+// Display - representation of the object printed by `display`
+func Display(obj Object) string {
+	return represent(obj, false)
+}
+
+// Write - external representation of the object printed by `write`:
+// strings are quoted, builtin procedures are printed with their names.
+// Pairs, vectors and records containing themselves are labelled as
+// #n= where they first appear and referred to as #n# later
+func Write(obj Object) string {
+	return represent(obj, true)
+}
+
+// represent - prints the object labelling its circular parts
+func represent(obj Object, written bool) string {
+	p := &printer{written: written, labels: make(map[Object]int)}
+	p.findCycles(obj, make(map[Object]bool))
+	p.print(obj)
+	return p.sb.String()
+}
+
+// printer - state of printing an object
+type printer struct {
+	sb      strings.Builder
+	written bool
+	// labels - objects that contain themselves, mapped to their
+	// labels, negative until the object is printed
+	labels map[Object]int
+	next   int
+}
+
+// mutable - reports whether the object may contain itself,
+// only those ones are tracked while printing
+func mutable(obj Object) bool {
+	switch obj.(type) {
+	case *Pair, *Vector, *Record:
+		return true
+	}
+
+	return false
+}
+
+// findCycles - marks objects reachable from themselves for labelling,
+// entered are the objects on the way from the printed one
+func (p *printer) findCycles(obj Object, entered map[Object]bool) {
+	if !mutable(obj) {
+		if vs, ok := obj.(Values); ok {
+			for _, v := range vs {
+				p.findCycles(v, entered)
+			}
+		}
+
+		return
+	}
+
+	if done, ok := entered[obj]; ok {
+		if !done {
+			p.labels[obj] = -1
+		}
+
+		return
+	}
+
+	entered[obj] = false
+	switch v := obj.(type) {
+	case *Pair:
+		p.findCycles(v.Car, entered)
+		p.findCycles(v.Cdr, entered)
+	case *Vector:
+		for _, item := range v.items {
+			p.findCycles(item, entered)
+		}
+	case *Record:
+		for _, field := range v.Fields {
+			p.findCycles(field, entered)
+		}
+	}
+
+	entered[obj] = true
+}
+
+// print - writes the object, circular ones are labelled
+func (p *printer) print(obj Object) {
+	if mutable(obj) {
+		if label, ok := p.labels[obj]; ok {
+			if label >= 0 {
+				fmt.Fprintf(&p.sb, "#%d#", label)
+				return
+			}
+
+			p.labels[obj] = p.next
+			fmt.Fprintf(&p.sb, "#%d=", p.next)
+			p.next++
+		}
+	}
+
+	switch v := obj.(type) {
+	case String:
+		if p.written {
+			p.sb.WriteString(`"` + escapes.Replace(string(v)) + `"`)
+		} else {
+			p.sb.WriteString(string(v))
+		}
+	case *Pair:
+		p.sb.WriteByte('(')
+		p.print(v.Car)
+		for rest := v.Cdr; rest != Null; {
+			next, ok := rest.(*Pair)
+			if _, labelled := p.labels[rest]; !ok || labelled {
+				p.sb.WriteString(" . ")
+				p.print(rest)
+				break
+			}
+
+			p.sb.WriteByte(' ')
+			p.print(next.Car)
+			rest = next.Cdr
+		}
+
+		p.sb.WriteByte(')')
+	case *Vector:
+		p.sb.WriteString("#(")
+		for i, item := range v.items {
+			if i > 0 {
+				p.sb.WriteByte(' ')
+			}
+
+			p.print(item)
+		}
+
+		p.sb.WriteByte(')')
+	case *Record:
+		p.sb.WriteString("#<record ")
+		p.sb.WriteString(v.Type.Name)
+		for i, name := range v.Type.Fields {
+			fmt.Fprintf(&p.sb, " %s: ", name)
+			p.print(v.Fields[i])
+		}
+
+		p.sb.WriteString(">")
+	case Values:
+		for i, item := range v {
+			if i > 0 {
+				p.sb.WriteByte(' ')
+			}
+
+			p.print(item)
+		}
+	case Callable:
+		if name, ok := PrimitiveName(obj); ok {
+			fmt.Fprintf(&p.sb, "#<procedure %s>", name)
+		} else if _, ok := v.(fmt.Stringer); ok {
+			fmt.Fprint(&p.sb, obj)
+		} else {
+			p.sb.WriteString("#<procedure>")
+		}
+	default:
+		fmt.Fprint(&p.sb, obj)
+	}
+}
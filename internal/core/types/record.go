@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+)
+
+// RecordType - record type created by define-record-type,
+// describes names of the record fields
+type RecordType struct {
+	Name   string
+	Fields []string
+}
+
+// Value - Object implementation
+func (rt *RecordType) Value() any {
+	return rt.Name
+}
+
+func (rt *RecordType) String() string {
+	return fmt.Sprintf("#<record-type %s>", rt.Name)
+}
+
+// Record - instance of the record type
+type Record struct {
+	Type   *RecordType
+	Fields []Object
+}
+
+// NewRecord - Record constructor, fields are
+// listed in the order of the record type ones
+func NewRecord(rt *RecordType, fields []Object) *Record {
+	return &Record{
+		Type:   rt,
+		Fields: fields,
+	}
+}
+
+// Value - Object implementation
+func (r *Record) Value() any {
+	return r.Fields
+}
+
+func (r *Record) String() string {
+	return Display(r)
+}
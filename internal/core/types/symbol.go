@@ -0,0 +1,10 @@
+package types
+
+// Symbol - wrapper for identifiers used as data, e.g. inside
+// quoted lists or vector literals
+type Symbol string
+
+// Value - Object implementation
+func (s Symbol) Value() any {
+	return s
+}
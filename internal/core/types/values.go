@@ -0,0 +1,37 @@
+package types
+
+import ()
+
+// Values - multiple values returned by `values`
+// with any count of objects but one, a single value
+// is always represented by the object itself
+type Values []Object
+
+// Value - Object implementation
+func (vs Values) Value() any {
+	return []Object(vs)
+}
+
+func (vs Values) String() string {
+	return Display(vs)
+}
+
+// MultipleValues - builds values object, returning
+// the object itself for a single value
+func MultipleValues(objs ...Object) Object {
+	if len(objs) == 1 {
+		return objs[0]
+	}
+
+	return Values(objs)
+}
+
+// ValuesOf - returns objects of multiple values,
+// or the single object otherwise
+func ValuesOf(obj Object) []Object {
+	if vs, ok := obj.(Values); ok {
+		return vs
+	}
+
+	return []Object{obj}
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// Vector - mutable fixed-length sequence of objects
+type Vector struct {
+	items []Object
+}
+
+// NewVector - Vector constructor from the given objects
+func NewVector(items ...Object) *Vector {
+	if items == nil {
+		items = make([]Object, 0)
+	}
+
+	return &Vector{
+		items: items,
+	}
+}
+
+// Value - Object implementation
+func (v *Vector) Value() any {
+	return v.items
+}
+
+// Items - returns underlying slice of vector elements
+func (v *Vector) Items() []Object {
+	return v.items
+}
+
+// Len - returns vector length
+func (v *Vector) Len() int {
+	return len(v.items)
+}
+
+// Ref - returns element at index k
+func (v *Vector) Ref(k int) (Object, error) {
+	if k < 0 || k >= len(v.items) {
+		return nil, fmt.Errorf("%w: index %d, vector length %d", errscm.ErrIndexOutOfRange, k, len(v.items))
+	}
+
+	return v.items[k], nil
+}
+
+// Set - replaces element at index k
+func (v *Vector) Set(k int, obj Object) error {
+	if k < 0 || k >= len(v.items) {
+		return fmt.Errorf("%w: index %d, vector length %d", errscm.ErrIndexOutOfRange, k, len(v.items))
+	}
+
+	v.items[k] = obj
+	return nil
+}
+
+func (v *Vector) String() string {
+	return Display(v)
+}
@@ -0,0 +1,320 @@
+package vectors
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// VectorOp - wrapper for builtin vector procedures
+type VectorOp func(args ...types.Object) (types.Object, error)
+
+func (v VectorOp) Call(args ...types.Object) (types.Object, error) {
+	return v(args...)
+}
+
+func (v VectorOp) Value() any {
+	return "PrimitiveOperation"
+}
+
+// Vector - `vector` primitive
+func Vector(args ...types.Object) (types.Object, error) {
+	items := make([]types.Object, len(args))
+	copy(items, args)
+	return types.NewVector(items...), nil
+}
+
+// MakeVector - `make-vector` primitive
+func MakeVector(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	k, err := check.Length(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var fill types.Object = types.NewNumber(0)
+	if len(args) == 2 {
+		fill = args[1]
+	}
+
+	items := make([]types.Object, k)
+	for i := range items {
+		items[i] = fill
+	}
+
+	return types.NewVector(items...), nil
+}
+
+// VectorRef - `vector-ref` primitive
+func VectorRef(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	v, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := check.Index(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Ref(k)
+}
+
+// VectorSet - `vector-set!` primitive
+func VectorSet(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 3, 3); err != nil {
+		return nil, err
+	}
+
+	v, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := check.Index(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, v.Set(k, args[2])
+}
+
+// VectorLength - `vector-length` primitive
+func VectorLength(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	v, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(int64(v.Len())), nil
+}
+
+// VectorToList - `vector->list` primitive
+func VectorToList(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 3); err != nil {
+		return nil, err
+	}
+
+	v, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(v.Len(), args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.List(v.Items()[start:end]...), nil
+}
+
+// ListToVector - `list->vector` primitive
+func ListToVector(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	items, err := types.ListToSlice(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewVector(items...), nil
+}
+
+// VectorFill - `vector-fill!` primitive
+func VectorFill(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 2, 4); err != nil {
+		return nil, err
+	}
+
+	v, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(v.Len(), args[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	items := v.Items()
+	for i := start; i < end; i++ {
+		items[i] = args[1]
+	}
+
+	return nil, nil
+}
+
+// VectorCopy - `vector-copy` primitive
+func VectorCopy(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 1, 3); err != nil {
+		return nil, err
+	}
+
+	v, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(v.Len(), args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]types.Object, end-start)
+	copy(items, v.Items()[start:end])
+	return types.NewVector(items...), nil
+}
+
+// VectorCopyTo - `vector-copy!` primitive, copies elements of
+// `from` vector into `to` vector starting at index `at`
+func VectorCopyTo(args ...types.Object) (types.Object, error) {
+	if err := check.Arity(args, 3, 5); err != nil {
+		return nil, err
+	}
+
+	to, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := check.Index(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := vector(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := check.Range(from.Len(), args[3:])
+	if err != nil {
+		return nil, err
+	}
+
+	if at > to.Len() || to.Len()-at < end-start {
+		return nil, fmt.Errorf(
+			"%w: cannot copy %d elements at index %d, vector length %d",
+			errscm.ErrIndexOutOfRange, end-start, at, to.Len(),
+		)
+	}
+
+	copy(to.Items()[at:], from.Items()[start:end])
+	return nil, nil
+}
+
+// VectorAppend - `vector-append` primitive
+func VectorAppend(args ...types.Object) (types.Object, error) {
+	items := make([]types.Object, 0)
+	for _, arg := range args {
+		v, err := vector(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, v.Items()...)
+	}
+
+	return types.NewVector(items...), nil
+}
+
+// VectorMap - `vector-map` primitive
+func VectorMap(args ...types.Object) (types.Object, error) {
+	return walk(args, func(results []types.Object) types.Object {
+		return types.NewVector(results...)
+	})
+}
+
+// VectorForEach - `vector-for-each` primitive
+func VectorForEach(args ...types.Object) (types.Object, error) {
+	return walk(args, func([]types.Object) types.Object {
+		return nil
+	})
+}
+
+// walk - calls procedure given as the first argument with elements
+// of the rest vector arguments until the shortest one is exhausted,
+// then passes the results to the finish func. The procedure is
+// applied by the evaluator, see types.Apply
+func walk(args []types.Object, finish func([]types.Object) types.Object) (types.Object, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: expected at least 2 args, got %d", errscm.ErrTooLittleArguments, len(args))
+	}
+
+	proc := args[0]
+	if _, ok := proc.(types.Callable); !ok {
+		return nil, fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, proc)
+	}
+
+	vs := make([]*types.Vector, len(args)-1)
+	length := -1
+	for i, arg := range args[1:] {
+		v, err := vector(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if length < 0 || v.Len() < length {
+			length = v.Len()
+		}
+		vs[i] = v
+	}
+
+	// results are collected into a list, which is never mutated,
+	// so the iteration may be re-entered by a continuation
+	var step func(i int, results types.Object) (types.Object, error)
+	step = func(i int, results types.Object) (types.Object, error) {
+		if i == length {
+			items, err := types.ListToSlice(results)
+			if err != nil {
+				return nil, err
+			}
+
+			for l, r := 0, len(items)-1; l < r; l, r = l+1, r-1 {
+				items[l], items[r] = items[r], items[l]
+			}
+
+			return finish(items), nil
+		}
+
+		callArgs := make([]types.Object, len(vs))
+		for j, v := range vs {
+			callArgs[j] = v.Items()[i]
+		}
+
+		return &types.Apply{
+			Proc: proc,
+			Args: callArgs,
+			Then: func(result types.Object) (types.Object, error) {
+				return step(i+1, types.Cons(result, results))
+			},
+		}, nil
+	}
+
+	return step(0, types.Null)
+}
+
+// vector - asserts that the object is a vector
+func vector(obj types.Object) (*types.Vector, error) {
+	v, ok := obj.(*types.Vector)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected vector, got %v", errscm.ErrUnexpectedType, obj)
+	}
+
+	return v, nil
+}
@@ -1,38 +1,67 @@
 package data
 
-import (
-	"github.com/Vallghall/gopherscm/internal/core"
-)
-
 // AST - represents Scheme program structure
 type AST struct {
 	Ctx      *Context
 	Token    *Token
 	Kind     Expr
 	Subtrees []*AST
+	// Origin - template identifier the identifier was renamed
+	// from by a macro expansion, Ctx of the renamed identifier
+	// is the macro definition context, where Origin is looked
+	// up when the renamed one is not bound
+	Origin *AST
 }
 
 // ASTRoot - constructor for AST
 func ASTRoot() *AST {
 	return &AST{
 		Kind:     Root,
-		Ctx:      NewContext(core.DefaultDefinitions()),
+		Ctx:      NewProgramContext(),
 		Subtrees: make([]*AST, 0),
 	}
 }
 
-// Nest - AST node constructor
+// Nest - AST node constructor for parenthesized forms,
+// t is the opening parenthesis and the form's elements,
+// including its head, are added as subtrees of the node.
+// The kind of the form is set by Classify, when all of
+// its elements are known
 func (ast *AST) Nest(t *Token) *AST {
 	node := &AST{
 		Token:    t,
-		Ctx:      ast.Ctx,
+		Kind:     CallExpr,
+		Subtrees: make([]*AST, 0),
+	}
+
+	ast.Subtrees = append(ast.Subtrees, node)
+
+	return node
+}
+
+// Classify - sets the kind of the form based on its head:
+// special forms are recognized by their keyword, all the
+// other forms are function calls
+func (ast *AST) Classify() {
+	if kind, ok := forms[ast.Head()]; ok {
+		ast.Kind = kind
+		return
+	}
+
+	ast.Kind = CallExpr
+}
+
+// NestVector - AST node constructor for vector and bytevector
+// literals, elements are added as subtrees of the node
+func (ast *AST) NestVector(t *Token) *AST {
+	node := &AST{
+		Token:    t,
+		Kind:     Vector,
 		Subtrees: make([]*AST, 0),
 	}
 
-	if t.Value() == "define" {
-		node.Kind = DefineExpr
-	} else {
-		node.Kind = CallExpr // all nested forms have functions as the first elem
+	if t.Value() == "#u8(" {
+		node.Kind = Bytevector
 	}
 
 	ast.Subtrees = append(ast.Subtrees, node)
@@ -45,19 +74,66 @@ func (ast *AST) Identifier() string {
 	return ast.Token.Value()
 }
 
+// IsForm - reports whether the node is a parenthesized form
+func (ast *AST) IsForm() bool {
+	return ast.Token != nil && ast.Token.Type() == Syntax && ast.Token.Value() == "("
+}
+
+// Name - returns identifier as it was written in the source,
+// identifiers renamed by macro expansions are traced back
+// to their origin
+func (ast *AST) Name() string {
+	for ast.Origin != nil {
+		ast = ast.Origin
+	}
+
+	return ast.Identifier()
+}
+
+// Head - returns name of the identifier in the head position of
+// the form, or an empty string if the head is not an identifier
+func (ast *AST) Head() string {
+	if !ast.IsForm() || len(ast.Subtrees) == 0 || ast.Subtrees[0].Kind != VariableRef {
+		return ""
+	}
+
+	return ast.Subtrees[0].Name()
+}
+
+// Split - splits elements of the form into the proper part and
+// the element following the dot, which is nil for proper lists.
+// Reports false if the dot is misplaced
+func (ast *AST) Split() ([]*AST, *AST, bool) {
+	n := len(ast.Subtrees)
+	for i, st := range ast.Subtrees {
+		if st.Kind != Dot {
+			continue
+		}
+
+		if i == 0 || i != n-2 || ast.Subtrees[n-1].Kind == Dot {
+			return nil, nil, false
+		}
+
+		return ast.Subtrees[:i], ast.Subtrees[n-1], true
+	}
+
+	return ast.Subtrees, nil, true
+}
+
 // Add - AST node constructor
 func (ast *AST) Add(t *Token) *AST {
 	var e Expr
 	switch t.Type() {
-	case Int, Float, String:
+	case Int, Float, String, Boolean, Keyword:
 		e = Literal
 	case Id:
 		e = VariableRef
+	case Syntax: // only the dot gets here, parentheses are handled by parser
+		e = Dot
 	default: // fill in later
 	}
 
 	node := &AST{
-		Ctx:      ast.Ctx,
 		Token:    t,
 		Kind:     e,
 		Subtrees: make([]*AST, 0),
@@ -67,3 +143,16 @@ func (ast *AST) Add(t *Token) *AST {
 
 	return node
 }
+
+// Rename - creates copy of the identifier under the new name,
+// which resolves to the original identifier within ctx while
+// the new name is not bound
+func (ast *AST) Rename(name string, ctx *Context) *AST {
+	return &AST{
+		Ctx:      ctx,
+		Token:    &Token{value: name, t: Id, meta: ast.Token.meta},
+		Kind:     VariableRef,
+		Subtrees: make([]*AST, 0),
+		Origin:   ast,
+	}
+}
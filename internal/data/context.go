@@ -1,6 +1,11 @@
 package data
 
-import "github.com/Vallghall/gopherscm/internal/core/types"
+import (
+	"sort"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+)
 
 // Context - context of the scope
 type Context struct {
@@ -8,6 +13,12 @@ type Context struct {
 	outerCtx *Context
 	// probably replace with sync.Map
 	symbolTable map[string]types.Object
+	// topLevel - the context is top-level one of a program,
+	// the only one libraries may be imported into
+	topLevel bool
+	// implicit - the outer context holds the builtins visible
+	// to the program until it imports libraries explicitly
+	implicit bool
 }
 
 // NewContext - Context constructor for the global
@@ -18,6 +29,23 @@ func NewContext(t map[string]types.Object) *Context {
 	}
 }
 
+// NewProgramContext - top-level context of a program, all the
+// builtins are visible within it until the first import
+func NewProgramContext() *Context {
+	ctx := NewContext(core.DefaultDefinitions()).Spawn()
+	ctx.implicit = true
+	return ctx
+}
+
+// DropImplicit - hides the builtins implicitly visible
+// to the program, so it sees the imported bindings only
+func (c *Context) DropImplicit() {
+	if c.implicit {
+		c.outerCtx = nil
+		c.implicit = false
+	}
+}
+
 // Spawn - creates child context, that points to
 // the parent context, with empty symbol table
 func (c *Context) Spawn() *Context {
@@ -42,7 +70,60 @@ func (c *Context) FindDef(s string) (types.Object, bool) {
 	return def, true
 }
 
-// Set - binds provided object to a given key within the current context
-func (c *Context) Set(key string, obj types.Object) {
+// Binding - finds the context the identifier is bound within,
+// the context itself or the nearest outer one
+func (c *Context) Binding(s string) (*Context, bool) {
+	for ctx := c; ctx != nil; ctx = ctx.outerCtx {
+		if _, ok := ctx.symbolTable[s]; ok {
+			return ctx, true
+		}
+	}
+
+	return nil, false
+}
+
+// Define - binds provided object to a given key within the current
+// context, shadowing bindings of the outer contexts
+func (c *Context) Define(key string, obj types.Object) {
 	c.symbolTable[key] = obj
 }
+
+// Assign - rebinds the nearest existing binding of the given key,
+// reports false if the key is not bound in any enclosing context
+func (c *Context) Assign(key string, obj types.Object) bool {
+	for ctx := c; ctx != nil; ctx = ctx.outerCtx {
+		if _, ok := ctx.symbolTable[key]; ok {
+			ctx.symbolTable[key] = obj
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetTopLevel - marks the context as top-level one of a program
+func (c *Context) SetTopLevel() {
+	c.topLevel = true
+}
+
+// TopLevel - reports whether the context is top-level one of a program
+func (c *Context) TopLevel() bool {
+	return c.topLevel
+}
+
+// Outer - returns the enclosing context, nil for the global one
+func (c *Context) Outer() *Context {
+	return c.outerCtx
+}
+
+// Names - returns sorted identifiers bound within
+// the context itself, not within the outer ones
+func (c *Context) Names() []string {
+	names := make([]string, 0, len(c.symbolTable))
+	for name := range c.symbolTable {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
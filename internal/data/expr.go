@@ -25,25 +25,190 @@ const (
 	DefineExpr
 	// Function - node containing function body
 	Function
+	// Vector - vector literal, evaluated into a new vector
+	// of its quoted elements
+	Vector
+	// Bytevector - bytevector literal, evaluated into
+	// a new bytevector of its elements
+	Bytevector
+	// LambdaExpr - anonymous function definition
+	LambdaExpr
+	// LetExpr - parallel bindings within a new scope,
+	// or a named let loop
+	LetExpr
+	// LetStarExpr - sequential bindings, each one in a new scope
+	LetStarExpr
+	// LetrecExpr - mutually recursive bindings
+	LetrecExpr
+	// LetrecStarExpr - mutually recursive bindings,
+	// initialised from left to right
+	LetrecStarExpr
+	// SetExpr - assignment to an existing variable
+	SetExpr
+	// BeginExpr - sequence of expressions evaluated within
+	// the current scope
+	BeginExpr
+	// DoExpr - iteration with variable steps and a termination clause
+	DoExpr
+	// AndExpr - short-circuiting conjunction
+	AndExpr
+	// OrExpr - short-circuiting disjunction
+	OrExpr
+	// IfExpr - two-way conditional
+	IfExpr
+	// CondExpr - multi-way conditional with clauses
+	CondExpr
+	// DefineStarExpr - definition of a function with
+	// optional and keyword parameters
+	DefineStarExpr
+	// LambdaStarExpr - anonymous function with optional
+	// and keyword parameters
+	LambdaStarExpr
+	// CaseLambdaExpr - function dispatching on the number of arguments
+	CaseLambdaExpr
+	// LetValuesExpr - bindings of multiple values within a new scope
+	LetValuesExpr
+	// LetStarValuesExpr - sequential bindings of multiple values
+	LetStarValuesExpr
+	// DefineValuesExpr - definition of variables from multiple values
+	DefineValuesExpr
+	// ReceiveExpr - binding of multiple values of a single expression
+	ReceiveExpr
+	// GuardExpr - evaluation of a body with exception handling clauses
+	GuardExpr
+	// DelayExpr - expression evaluated when forced, memoized
+	DelayExpr
+	// DelayForceExpr - delayed expression evaluating to a promise,
+	// forced iteratively
+	DelayForceExpr
+	// StreamConsExpr - stream pair of delayed elements
+	StreamConsExpr
+	// ParameterizeExpr - evaluation of a body with parameter
+	// objects dynamically rebound
+	ParameterizeExpr
+	// DefineSyntaxExpr - definition of a macro
+	DefineSyntaxExpr
+	// LetSyntaxExpr - macros bound within a new scope
+	LetSyntaxExpr
+	// LetrecSyntaxExpr - mutually recursive macros
+	// bound within a new scope
+	LetrecSyntaxExpr
+	// SyntaxRulesExpr - pattern language transformer,
+	// evaluated into a macro
+	SyntaxRulesExpr
+	// QuoteExpr - datum taken literally without evaluation
+	QuoteExpr
+	// DefineRecordTypeExpr - definition of a record type
+	// and its procedures
+	DefineRecordTypeExpr
+	// DefineLibraryExpr - definition of a library
+	// with its own top-level context
+	DefineLibraryExpr
+	// ImportExpr - import of library bindings
+	ImportExpr
+	// IncludeExpr - forms read from the files,
+	// evaluated in place of the expression
+	IncludeExpr
+	// CondExpandExpr - expressions selected by
+	// the implementation features
+	CondExpandExpr
+	// Dot - marker separating the last element of an improper list
+	Dot
 	// Root - AST root unique expressions kind
 	Root = 9999
 )
 
+// forms - special forms recognized by the identifier in head position
+var forms = map[string]Expr{
+	"define":             DefineExpr,
+	"lambda":             LambdaExpr,
+	"let":                LetExpr,
+	"let*":               LetStarExpr,
+	"letrec":             LetrecExpr,
+	"letrec*":            LetrecStarExpr,
+	"set!":               SetExpr,
+	"begin":              BeginExpr,
+	"do":                 DoExpr,
+	"and":                AndExpr,
+	"or":                 OrExpr,
+	"if":                 IfExpr,
+	"cond":               CondExpr,
+	"define*":            DefineStarExpr,
+	"lambda*":            LambdaStarExpr,
+	"case-lambda":        CaseLambdaExpr,
+	"let-values":         LetValuesExpr,
+	"let*-values":        LetStarValuesExpr,
+	"define-values":      DefineValuesExpr,
+	"receive":            ReceiveExpr,
+	"guard":              GuardExpr,
+	"delay":              DelayExpr,
+	"delay-force":        DelayForceExpr,
+	"stream-cons":        StreamConsExpr,
+	"parameterize":       ParameterizeExpr,
+	"define-syntax":      DefineSyntaxExpr,
+	"let-syntax":         LetSyntaxExpr,
+	"letrec-syntax":      LetrecSyntaxExpr,
+	"syntax-rules":       SyntaxRulesExpr,
+	"quote":              QuoteExpr,
+	"define-record-type": DefineRecordTypeExpr,
+	"define-library":     DefineLibraryExpr,
+	"import":             ImportExpr,
+	"include":            IncludeExpr,
+	"cond-expand":        CondExpandExpr,
+}
+
+// exprNames - names of expression kinds used in JSON output
+var exprNames = map[Expr]string{
+	Literal:              "Literal",
+	CallExpr:             "CallExpr",
+	VariableRef:          "VariableRef",
+	DefineExpr:           "DefineExpr",
+	Function:             "Function",
+	Vector:               "Vector",
+	Bytevector:           "Bytevector",
+	LambdaExpr:           "LambdaExpr",
+	LetExpr:              "LetExpr",
+	LetStarExpr:          "LetStarExpr",
+	LetrecExpr:           "LetrecExpr",
+	LetrecStarExpr:       "LetrecStarExpr",
+	SetExpr:              "SetExpr",
+	BeginExpr:            "BeginExpr",
+	DoExpr:               "DoExpr",
+	AndExpr:              "AndExpr",
+	OrExpr:               "OrExpr",
+	IfExpr:               "IfExpr",
+	CondExpr:             "CondExpr",
+	DefineStarExpr:       "DefineStarExpr",
+	LambdaStarExpr:       "LambdaStarExpr",
+	CaseLambdaExpr:       "CaseLambdaExpr",
+	LetValuesExpr:        "LetValuesExpr",
+	LetStarValuesExpr:    "LetStarValuesExpr",
+	DefineValuesExpr:     "DefineValuesExpr",
+	ReceiveExpr:          "ReceiveExpr",
+	GuardExpr:            "GuardExpr",
+	DelayExpr:            "DelayExpr",
+	DelayForceExpr:       "DelayForceExpr",
+	StreamConsExpr:       "StreamConsExpr",
+	ParameterizeExpr:     "ParameterizeExpr",
+	DefineSyntaxExpr:     "DefineSyntaxExpr",
+	LetSyntaxExpr:        "LetSyntaxExpr",
+	LetrecSyntaxExpr:     "LetrecSyntaxExpr",
+	SyntaxRulesExpr:      "SyntaxRulesExpr",
+	QuoteExpr:            "QuoteExpr",
+	DefineRecordTypeExpr: "DefineRecordTypeExpr",
+	DefineLibraryExpr:    "DefineLibraryExpr",
+	ImportExpr:           "ImportExpr",
+	IncludeExpr:          "IncludeExpr",
+	CondExpandExpr:       "CondExpandExpr",
+	Dot:                  "Dot",
+	Root:                 "Root",
+}
+
 func (e Expr) MarshalJSON() ([]byte, error) {
-	switch e {
-	case Literal:
-		return json.Marshal("Literal")
-	case CallExpr:
-		return json.Marshal("CallExpr")
-	case DefineExpr:
-		return json.Marshal("DefineExpr")
-	case VariableRef:
-		return json.Marshal("VariableRef")
-	case Function:
-		return json.Marshal("Function")
-	case Root:
-		return json.Marshal("Root")
-	default:
+	name, ok := exprNames[e]
+	if !ok {
 		return nil, ErrUnsupportedExprKind
 	}
+
+	return json.Marshal(name)
 }
@@ -4,6 +4,9 @@ package data
 
 // Meta - stores Meta for analytics and error positioning
 type Meta struct {
+	// file - name of the source file, empty
+	// for the code that is not read from a file
+	file string
 	line int
 	pos  int
 	// TODO: add an input buffer for better error printing
@@ -16,14 +19,38 @@ func NewMeta() *Meta {
 	}
 }
 
+// NewFileMeta - constructor for meta of the source file
+func NewFileMeta(file string) *Meta {
+	return &Meta{
+		file: file,
+		line: 1,
+	}
+}
+
 // Current - copies the values for the current meta
 func (m *Meta) Current() *Meta {
 	return &Meta{
+		file: m.file,
 		line: m.line,
 		pos:  m.pos,
 	}
 }
 
+// File - source file name getter
+func (m *Meta) File() string {
+	return m.file
+}
+
+// Line - line number getter
+func (m *Meta) Line() int {
+	return m.line
+}
+
+// Pos - position within the line getter
+func (m *Meta) Pos() int {
+	return m.pos
+}
+
 // Inc - increments position
 func (m *Meta) Inc() {
 	m.pos++
@@ -19,6 +19,8 @@ const (
 	Float
 	String
 	Quote
+	Boolean
+	Keyword
 )
 
 func (t Type) MarshalJSON() ([]byte, error) {
@@ -35,6 +37,10 @@ func (t Type) MarshalJSON() ([]byte, error) {
 		return json.Marshal("String")
 	case Quote:
 		return json.Marshal("Quote")
+	case Boolean:
+		return json.Marshal("Boolean")
+	case Keyword:
+		return json.Marshal("Keyword")
 	default:
 		return nil, ErrUnsupportedTokenType
 	}
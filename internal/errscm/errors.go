@@ -32,6 +32,54 @@ func (se *SyntaxError) Unwrap() error {
 	return se.err
 }
 
+// RuntimeError - error of evaluation that includes
+// the position of the expression it occurred in
+type RuntimeError struct {
+	err      error
+	file     string
+	line     int
+	position int
+}
+
+// ReportRuntimeError - constructor for RuntimeError,
+// file is empty for the code not read from a file
+func ReportRuntimeError(file string, line, pos int, err error) error {
+	return &RuntimeError{
+		err:      err,
+		file:     file,
+		line:     line,
+		position: pos,
+	}
+}
+
+// Error - error interface implementation
+func (re *RuntimeError) Error() string {
+	if re.file != "" {
+		return fmt.Sprintf("ERROR in %s at line %d, position %d: %s", re.file, re.line, re.position, re.err.Error())
+	}
+
+	return fmt.Sprintf("ERROR at line %d, position %d: %s", re.line, re.position, re.err.Error())
+}
+
+// Unwrap - unwrap interface implementation
+func (re *RuntimeError) Unwrap() error {
+	return re.err
+}
+
+// ExitError - request to exit the program with the status,
+// returned by `exit` and `emergency-exit` to unwind the evaluation
+type ExitError struct {
+	Code int
+	// Emergency - the program exits without running the
+	// outstanding dynamic-wind after thunks and flushing ports
+	Emergency bool
+}
+
+// Error - error interface implementation
+func (ee *ExitError) Error() string {
+	return fmt.Sprintf("exit with status %d", ee.Code)
+}
+
 var (
 	// ErrEndOfInput - signals of unexpected end of input
 	ErrEndOfInput                  = errors.New("cursor is out of range")
@@ -47,4 +95,18 @@ var (
 	ErrUnexpectedNumberOfArguments = errors.New("unexpected number of arguments")
 	ErrTooLittleArguments          = errors.New("too little arguments")
 	ErrUnsupported                 = errors.New("unsupported")
+	ErrUnexpectedType              = errors.New("unexpected argument type")
+	ErrIndexOutOfRange             = errors.New("index out of range")
+	ErrDivisionByZero              = errors.New("division by zero")
+	ErrInvalidEncoding             = errors.New("invalid encoding")
+	ErrBadSyntax                   = errors.New("bad syntax")
+	ErrUseBeforeInit               = errors.New("variable used before initialisation")
+	ErrUnboundVariable             = errors.New("unbound variable")
+	ErrUnknownKeyword              = errors.New("unknown keyword argument")
+	ErrWrongNumberOfValues         = errors.New("wrong number of values")
+	ErrUncaughtException           = errors.New("uncaught exception")
+	ErrNonContinuable              = errors.New("handler returned from non-continuable exception")
+	ErrKeyNotFound                 = errors.New("key not found")
+	ErrUnknownLibrary              = errors.New("unknown library")
+	ErrImportCycle                 = errors.New("import cycle")
 )
@@ -0,0 +1,101 @@
+package interp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// CaseLambda - procedure made of several clauses,
+// the first clause accepting the number of arguments is called
+type CaseLambda struct {
+	// Name - name the procedure is defined with, used in error messages
+	Name    string
+	Clauses []*Func
+}
+
+// Value - types.Object interface implementation
+func (c *CaseLambda) Value() any {
+	return "case-lambda"
+}
+
+func (c *CaseLambda) String() string {
+	return fmt.Sprintf("#<procedure %s>", c.name())
+}
+
+// Arities - types.Introspectable interface implementation
+func (c *CaseLambda) Arities() []types.Arity {
+	arities := make([]types.Arity, len(c.Clauses))
+	for i, clause := range c.Clauses {
+		arities[i] = clause.arity()
+	}
+
+	return arities
+}
+
+// Call - types.Callable interface implementation
+func (c *CaseLambda) Call(args ...types.Object) (types.Object, error) {
+	return callProc(c, args, nil)
+}
+
+// dispatch - selects the first clause accepting the number of arguments
+func (c *CaseLambda) dispatch(args []types.Object) (*Func, error) {
+	for _, clause := range c.Clauses {
+		if clause.arity().Accepts(len(args)) {
+			return clause, nil
+		}
+	}
+
+	accepted := make([]string, len(c.Clauses))
+	for i, clause := range c.Clauses {
+		a := clause.arity()
+		switch {
+		case a.Max < 0:
+			accepted[i] = fmt.Sprintf("at least %d", a.Min)
+		case a.Min == a.Max:
+			accepted[i] = fmt.Sprint(a.Min)
+		default:
+			accepted[i] = fmt.Sprintf("%d to %d", a.Min, a.Max)
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"%w: %s expected %s args, got %d",
+		errscm.ErrUnexpectedNumberOfArguments, c.name(), strings.Join(accepted, " or "), len(args),
+	)
+}
+
+// name - returns procedure name for error messages
+func (c *CaseLambda) name() string {
+	if c.Name == "" {
+		return "anonymous case-lambda"
+	}
+
+	return c.Name
+}
+
+// caseLambda - (case-lambda (formals body...) ...), every clause
+// is a function closed over the current context
+func caseLambda(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	cl := &CaseLambda{
+		Clauses: make([]*Func, 0, len(ast.Subtrees)-1),
+	}
+
+	for _, clause := range ast.Subtrees[1:] {
+		if !clause.IsForm() || len(clause.Subtrees) < 2 {
+			return nil, fmt.Errorf("%w: case-lambda clause must be (formals body...)", errscm.ErrBadSyntax)
+		}
+
+		fn, err := newFunc(clause.Subtrees[0], clause.Subtrees[1:], ctx, false)
+		if err != nil {
+			return nil, err
+		}
+
+		cl.Clauses = append(cl.Clauses, fn)
+	}
+
+	return cl, nil
+}
@@ -0,0 +1,131 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// ifExpr - (if test consequent [alternative]), the chosen
+// branch is in tail position
+func ifExpr(m *machine, ast *data.AST, ctx *data.Context) error {
+	if n := len(ast.Subtrees); n < 3 || n > 4 {
+		return fmt.Errorf("%w: expected 2 or 3 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, n-1)
+	}
+
+	m.push(ifFrame{ast: ast, ctx: ctx})
+	m.eval(ast.Subtrees[1], ctx)
+	return nil
+}
+
+// ifFrame - chooses the branch by the test value
+type ifFrame struct {
+	ast *data.AST
+	ctx *data.Context
+}
+
+func (f ifFrame) resume(m *machine, test types.Object) error {
+	if err := single(test); err != nil {
+		return err
+	}
+
+	switch {
+	case types.IsTrue(test):
+		m.eval(f.ast.Subtrees[2], f.ctx)
+	case len(f.ast.Subtrees) == 4:
+		m.eval(f.ast.Subtrees[3], f.ctx)
+	default:
+		m.ret(nil)
+	}
+
+	return nil
+}
+
+// cond - evaluates tests of the clauses in order until one of them
+// is true, then evaluates the clause's body in tail position.
+// Supported clauses are (test expr...), (test), (test => receiver)
+// and the final (else expr...)
+func cond(m *machine, ast *data.AST, ctx *data.Context) error {
+	return condClause(m, ast.Subtrees[1:], ctx, nil)
+}
+
+// condClause - evaluates the first of the clauses, when none
+// of them applies continues with otherwise, if it is given
+func condClause(m *machine, clauses []*data.AST, ctx *data.Context, otherwise func(*machine) error) error {
+	if len(clauses) == 0 {
+		if otherwise != nil {
+			return otherwise(m)
+		}
+
+		m.ret(nil)
+		return nil
+	}
+
+	clause := clauses[0]
+	if !clause.IsForm() || len(clause.Subtrees) == 0 {
+		return fmt.Errorf("%w: cond clause must be a non-empty list", errscm.ErrBadSyntax)
+	}
+
+	if clause.Head() == "else" {
+		if len(clauses) != 1 {
+			return fmt.Errorf("%w: else must be the last cond clause", errscm.ErrBadSyntax)
+		}
+
+		return sequence(m, clause.Subtrees[1:], ctx)
+	}
+
+	m.push(condFrame{clauses: clauses, ctx: ctx, otherwise: otherwise})
+	m.eval(clause.Subtrees[0], ctx)
+	return nil
+}
+
+// condFrame - evaluates body of the first clause when
+// its test is true, goes on with the rest clauses otherwise
+type condFrame struct {
+	clauses   []*data.AST
+	ctx       *data.Context
+	otherwise func(*machine) error
+}
+
+func (f condFrame) resume(m *machine, test types.Object) error {
+	if err := single(test); err != nil {
+		return err
+	}
+
+	if !types.IsTrue(test) {
+		return condClause(m, f.clauses[1:], f.ctx, f.otherwise)
+	}
+
+	body := f.clauses[0].Subtrees[1:]
+	if len(body) == 0 {
+		m.ret(test)
+		return nil
+	}
+
+	if body[0].Kind == data.VariableRef && body[0].Name() == "=>" {
+		if len(body) != 2 {
+			return fmt.Errorf("%w: => expects a single receiver", errscm.ErrBadSyntax)
+		}
+
+		m.push(receiverFrame{test: test})
+		m.eval(body[1], f.ctx)
+		return nil
+	}
+
+	return sequence(m, body, f.ctx)
+}
+
+// receiverFrame - applies the receiver of => clause to the test value
+type receiverFrame struct {
+	test types.Object
+}
+
+func (f receiverFrame) resume(m *machine, receiver types.Object) error {
+	if err := single(receiver); err != nil {
+		return err
+	}
+
+	return m.apply(receiver, []types.Object{f.test})
+}
@@ -0,0 +1,230 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+)
+
+func init() {
+	core.Register("scheme base", "call-with-current-continuation", control(callCC))
+	core.Register("scheme base", "call/cc", control(callCC))
+	core.Register("scheme base", "dynamic-wind", control(dynamicWind))
+}
+
+// Continuation - captured rest of the computation, calling
+// it abandons the current continuation and returns its
+// arguments to the captured one. Continuations may be
+// called any number of times, even after they have returned
+type Continuation struct {
+	k        *cont
+	base     *cont
+	winders  *winder
+	handlers *handler
+}
+
+// Value - types.Object interface implementation
+func (c *Continuation) Value() any {
+	return "continuation"
+}
+
+func (c *Continuation) String() string {
+	return "#<continuation>"
+}
+
+// Arities - types.Introspectable interface implementation
+func (c *Continuation) Arities() []types.Arity {
+	return []types.Arity{{Min: 0, Max: -1}}
+}
+
+// Call - types.Callable interface implementation. Called from Go
+// code the continuation is passed over to the evaluator loop
+// it belongs to as an error
+func (c *Continuation) Call(args ...types.Object) (types.Object, error) {
+	return nil, &escape{c: c, values: args}
+}
+
+// escape - continuation invoked within an evaluator loop nested
+// in a Go call, which is passed through the Go code up to the loop
+// the continuation belongs to. Continuations captured within
+// finished nested loops cannot be resumed
+type escape struct {
+	c      *Continuation
+	values []types.Object
+}
+
+func (e *escape) Error() string {
+	return "continuation invoked outside of its extent"
+}
+
+// winder - before and after thunks of the dynamic-wind extent
+type winder struct {
+	before types.Object
+	after  types.Object
+	next   *winder
+	depth  int
+}
+
+// depth - nesting level of the dynamic-wind extents
+func depth(w *winder) int {
+	if w == nil {
+		return 0
+	}
+
+	return w.depth
+}
+
+// windStep - thunk to run within the dynamic-wind extents
+// while moving from one continuation to another
+type windStep struct {
+	thunk   types.Object
+	winders *winder
+}
+
+// windPath - thunks to run while moving from the extents to
+// the others: after thunks of the left extents from the innermost
+// one, then before thunks of the entered ones from the outermost
+func windPath(from, to *winder) []windStep {
+	var exit, enter []windStep
+	for depth(from) > depth(to) {
+		exit = append(exit, windStep{thunk: from.after, winders: from.next})
+		from = from.next
+	}
+
+	for depth(to) > depth(from) {
+		enter = append(enter, windStep{thunk: to.before, winders: to.next})
+		to = to.next
+	}
+
+	for from != to {
+		exit = append(exit, windStep{thunk: from.after, winders: from.next})
+		enter = append(enter, windStep{thunk: to.before, winders: to.next})
+		from, to = from.next, to.next
+	}
+
+	for i := len(enter) - 1; i >= 0; i-- {
+		exit = append(exit, enter[i])
+	}
+
+	return exit
+}
+
+// capture - captures the current continuation
+func (m *machine) capture() *Continuation {
+	return &Continuation{
+		k:        m.k,
+		base:     m.base,
+		winders:  m.winders,
+		handlers: m.handlers,
+	}
+}
+
+// throw - returns values to the continuation
+func (m *machine) throw(c *Continuation, values []types.Object) error {
+	return m.jump(c, func(m *machine) error {
+		m.ret(types.MultipleValues(values...))
+		return nil
+	})
+}
+
+// jump - moves to the continuation, running the thunks of the
+// dynamic-wind extents being left and entered first, then goes on
+// with the landing func there
+func (m *machine) jump(c *Continuation, land func(*machine) error) error {
+	return m.rewind(windPath(m.winders, c.winders), c, land)
+}
+
+// rewind - runs the first of the thunks, the rest of them and
+// the landing wait in the frame
+func (m *machine) rewind(steps []windStep, c *Continuation, land func(*machine) error) error {
+	if len(steps) == 0 {
+		m.k, m.winders, m.handlers = c.k, c.winders, c.handlers
+		return land(m)
+	}
+
+	m.winders = steps[0].winders
+	m.push(rewindFrame{steps: steps[1:], c: c, land: land})
+	return m.apply(steps[0].thunk, nil)
+}
+
+// rewindFrame - continues moving to the continuation
+// after a dynamic-wind thunk
+type rewindFrame struct {
+	steps []windStep
+	c     *Continuation
+	land  func(*machine) error
+}
+
+func (f rewindFrame) resume(m *machine, _ types.Object) error {
+	return m.rewind(f.steps, f.c, f.land)
+}
+
+// callCC - `call-with-current-continuation` primitive: calls the
+// procedure with the current continuation as its argument
+func callCC(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return m.apply(args[0], []types.Object{m.capture()})
+}
+
+// dynamicWind - `dynamic-wind` primitive: (dynamic-wind before thunk after)
+// calls the thunk, running before every time the control enters
+// the thunk's extent and after every time it leaves it, whether
+// by return, continuation or error
+func dynamicWind(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 3, 3); err != nil {
+		return err
+	}
+
+	for _, thunk := range args {
+		if _, ok := thunk.(types.Callable); !ok {
+			return fmt.Errorf(`"%v" is not a function`, thunk)
+		}
+	}
+
+	m.push(windFrame{before: args[0], thunk: args[1], after: args[2]})
+	return m.apply(args[0], nil)
+}
+
+// windFrame - enters the extent after the before thunk
+// and calls the thunk within it
+type windFrame struct {
+	before, thunk, after types.Object
+}
+
+func (f windFrame) resume(m *machine, _ types.Object) error {
+	m.winders = &winder{
+		before: f.before,
+		after:  f.after,
+		next:   m.winders,
+		depth:  depth(m.winders) + 1,
+	}
+
+	m.push(unwindFrame{})
+	return m.apply(f.thunk, nil)
+}
+
+// unwindFrame - leaves the extent after the thunk returns
+// and calls the after thunk
+type unwindFrame struct{}
+
+func (unwindFrame) resume(m *machine, value types.Object) error {
+	w := m.winders
+	m.winders = w.next
+	m.push(returnFrame{value: value})
+	return m.apply(w.after, nil)
+}
+
+// returnFrame - returns the value, ignoring the one it gets
+type returnFrame struct {
+	value types.Object
+}
+
+func (f returnFrame) resume(m *machine, _ types.Object) error {
+	m.ret(f.value)
+	return nil
+}
@@ -0,0 +1,69 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+func init() {
+	core.Register("scheme base", "apply", control(applyProc))
+	core.Register("scheme base", "call-with-values", control(callWithValues))
+}
+
+// control - builtin procedure implemented within the evaluator,
+// it continues the evaluation instead of returning a value
+type control func(m *machine, args []types.Object) error
+
+// Call - types.Callable interface implementation
+func (c control) Call(args ...types.Object) (types.Object, error) {
+	return callProc(c, args, nil)
+}
+
+// Value - types.Object interface implementation
+func (c control) Value() any {
+	return "PrimitiveOperation"
+}
+
+// applyProc - `apply` primitive: (apply proc arg... list) calls
+// the procedure with the leading arguments followed by the list elements
+func applyProc(m *machine, args []types.Object) error {
+	if len(args) < 2 {
+		return fmt.Errorf("%w: expected at least 2 args, got %d", errscm.ErrTooLittleArguments, len(args))
+	}
+
+	last := len(args) - 1
+	spread, err := types.ListToSlice(args[last])
+	if err != nil {
+		return err
+	}
+
+	callArgs := make([]types.Object, 0, last-1+len(spread))
+	callArgs = append(callArgs, args[1:last]...)
+	callArgs = append(callArgs, spread...)
+
+	return m.apply(args[0], callArgs)
+}
+
+// callWithValues - `call-with-values` primitive: calls the producer
+// without arguments and passes the values it returns to the consumer
+func callWithValues(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return err
+	}
+
+	m.push(consumerFrame{consumer: args[1]})
+	return m.apply(args[0], nil)
+}
+
+// consumerFrame - applies the consumer to the produced values
+type consumerFrame struct {
+	consumer types.Object
+}
+
+func (f consumerFrame) resume(m *machine, value types.Object) error {
+	return m.apply(f.consumer, types.ValuesOf(value))
+}
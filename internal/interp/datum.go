@@ -0,0 +1,88 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// quote - (quote datum) returns the datum without evaluating it
+func quote(ast *data.AST) (types.Object, error) {
+	if len(ast.Subtrees) != 2 {
+		return nil, fmt.Errorf("%w: expected 1 arg, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
+	}
+
+	return datum(ast.Subtrees[1])
+}
+
+// datum - converts AST node into data without evaluating it,
+// identifiers become symbols and nested forms become lists
+func datum(ast *data.AST) (types.Object, error) {
+	return toData(ast, symbol)
+}
+
+// symbol - converts identifier into symbol
+func symbol(id *data.AST) types.Object {
+	return types.Symbol(id.Name())
+}
+
+// toData - converts AST node into data, identifiers are
+// converted by the given function
+func toData(ast *data.AST, ident func(*data.AST) types.Object) (types.Object, error) {
+	switch ast.Kind {
+	case data.Literal:
+		return evalLiteral(ast)
+	case data.VariableRef:
+		return ident(ast), nil
+	case data.Vector:
+		items, err := datums(ast.Subtrees, ident)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewVector(items...), nil
+	case data.Bytevector:
+		return evalBytevector(ast)
+	}
+
+	// the rest are nested forms, possibly improper lists
+	elems, rest, ok := ast.Split()
+	if !ok {
+		return nil, fmt.Errorf("%w: misplaced dot", errscm.ErrBadSyntax)
+	}
+
+	items, err := datums(elems, ident)
+	if err != nil {
+		return nil, err
+	}
+
+	var list types.Object = types.Null
+	if rest != nil {
+		if list, err = toData(rest, ident); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := len(items) - 1; i >= 0; i-- {
+		list = types.Cons(items[i], list)
+	}
+
+	return list, nil
+}
+
+// datums - converts every given AST node into data
+func datums(asts []*data.AST, ident func(*data.AST) types.Object) ([]types.Object, error) {
+	items := make([]types.Object, len(asts))
+	for i, st := range asts {
+		item, err := toData(st, ident)
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = item
+	}
+
+	return items, nil
+}
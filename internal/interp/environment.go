@@ -0,0 +1,175 @@
+package interp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+func init() {
+	core.Register("scheme eval", "eval", control(evalProc))
+	core.Register("scheme eval", "environment", control(environment))
+	core.Register("scheme repl", "interaction-environment", control(interactionEnvironment))
+	core.Register("scheme r5rs", "scheme-report-environment", control(schemeReportEnvironment))
+	core.Register("scheme r5rs", "null-environment", control(nullEnvironment))
+}
+
+// interaction - top-level context of the program being evaluated
+var interaction *data.Context
+
+// SetInteractionEnvironment - makes the context top-level one
+// for the expressions evaluated by Eval, e.g. in the REPL
+func SetInteractionEnvironment(ctx *data.Context) {
+	interaction = ctx
+}
+
+// interactionContext - returns top-level context of the program,
+// a new one with all the builtins if no program was walked yet
+func interactionContext() *data.Context {
+	if interaction == nil {
+		interaction = data.NewContext(core.DefaultDefinitions())
+		interaction.SetTopLevel()
+	}
+
+	return interaction
+}
+
+// Environment - evaluation context as a Scheme value
+type Environment struct {
+	ctx *data.Context
+}
+
+// Value - types.Object interface implementation
+func (env *Environment) Value() any {
+	return env.ctx
+}
+
+func (env *Environment) String() string {
+	return "#<environment>"
+}
+
+// evalProc - `eval` primitive: (eval expr [env]) evaluates the datum as
+// an expression within the environment, the interaction environment
+// by default. Definitions are made in the environment itself
+func evalProc(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return err
+	}
+
+	ctx := interactionContext()
+	if len(args) == 2 {
+		env, ok := args[1].(*Environment)
+		if !ok {
+			return fmt.Errorf("%w: expected environment, got %v", errscm.ErrUnexpectedType, args[1])
+		}
+
+		ctx = env.ctx
+	}
+
+	ast, err := code(m, args[0])
+	if err != nil {
+		return err
+	}
+
+	m.eval(ast, ctx)
+	return nil
+}
+
+// code - converts datum into the expression,
+// placed at the expression being evaluated
+func code(m *machine, obj types.Object) (*data.AST, error) {
+	at := m.form
+	if at == nil || at.Token == nil || at.Token.Meta() == nil {
+		at = &data.AST{Token: data.TokenFromMeta(data.NewMeta()).Set(data.Syntax, '(')}
+	}
+
+	return newExpansion(nil, at).fromData(obj)
+}
+
+// environment - `environment` primitive: (environment set ...)
+// creates environment with only the bindings of the import sets
+func environment(m *machine, args []types.Object) error {
+	ctx := data.NewContext(make(map[string]types.Object))
+	for _, arg := range args {
+		set, err := code(m, arg)
+		if err != nil {
+			return err
+		}
+
+		defs, err := importSet(set)
+		if err != nil {
+			return err
+		}
+
+		for name, obj := range defs {
+			ctx.Define(name, obj)
+		}
+	}
+
+	m.ret(&Environment{ctx: ctx})
+	return nil
+}
+
+// interactionEnvironment - `interaction-environment` primitive:
+// top-level environment of the program
+func interactionEnvironment(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return err
+	}
+
+	m.ret(&Environment{ctx: interactionContext()})
+	return nil
+}
+
+// schemeReportEnvironment - `scheme-report-environment` primitive:
+// (scheme-report-environment 5) creates environment with the
+// bindings of the standard scheme libraries
+func schemeReportEnvironment(m *machine, args []types.Object) error {
+	if err := reportVersion(args); err != nil {
+		return err
+	}
+
+	defs := make(map[string]types.Object)
+	for _, name := range core.Libraries() {
+		if !strings.HasPrefix(name, "scheme ") {
+			continue
+		}
+
+		lib, _ := core.Library(name)
+		for def, obj := range lib {
+			defs[def] = obj
+		}
+	}
+
+	m.ret(&Environment{ctx: data.NewContext(defs)})
+	return nil
+}
+
+// nullEnvironment - `null-environment` primitive: (null-environment 5)
+// creates environment with the syntactic keywords only
+func nullEnvironment(m *machine, args []types.Object) error {
+	if err := reportVersion(args); err != nil {
+		return err
+	}
+
+	m.ret(&Environment{ctx: data.NewContext(make(map[string]types.Object))})
+	return nil
+}
+
+// reportVersion - checks that the only argument is version 5 of the report
+func reportVersion(args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	if n, ok := args[0].(*types.Number); !ok || n.Value() != int64(5) {
+		return fmt.Errorf("%w: expected report version 5, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package interp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+func init() {
+	core.Register("scheme base", "raise", control(raiseProc))
+	core.Register("scheme base", "raise-continuable", control(raiseContinuable))
+	core.Register("scheme base", "with-exception-handler", control(withExceptionHandler))
+}
+
+// handler - exception handler within the dynamic environment
+type handler struct {
+	proc types.Object
+	next *handler
+}
+
+// uncaught - object raised without a handler, which is passed
+// through the Go code as an error
+type uncaught struct {
+	obj types.Object
+}
+
+func (u *uncaught) Error() string {
+	return fmt.Sprintf("%v: %v", errscm.ErrUncaughtException, u.obj)
+}
+
+// Unwrap - unwrap interface implementation
+func (u *uncaught) Unwrap() error {
+	return errscm.ErrUncaughtException
+}
+
+// condition - object raised for the error
+func condition(err error) types.Object {
+	var u *uncaught
+	if errors.As(err, &u) {
+		return u.obj
+	}
+
+	return types.ErrorOf(err)
+}
+
+// raise - calls the current handler with the object within the
+// dynamic environment of the raise, except for the handler, which
+// is replaced with the outer one for the call. Handler returning
+// from non-continuable raise raises a secondary exception
+func (m *machine) raise(obj types.Object, continuable bool) error {
+	h := m.handlers
+	if h == nil {
+		if e, ok := obj.(*types.ErrorObject); ok {
+			return e
+		}
+
+		return &uncaught{obj: obj}
+	}
+
+	m.push(raiseFrame{obj: obj, continuable: continuable, handler: h})
+	m.handlers = h.next
+	return m.apply(h.proc, []types.Object{obj})
+}
+
+// raiseFrame - returns the value of the handler to the raise
+type raiseFrame struct {
+	obj         types.Object
+	continuable bool
+	handler     *handler
+}
+
+func (f raiseFrame) resume(m *machine, value types.Object) error {
+	if !f.continuable {
+		return m.raise(&types.ErrorObject{
+			Message:   errscm.ErrNonContinuable.Error(),
+			Irritants: []types.Object{f.obj},
+			Err:       errscm.ErrNonContinuable,
+		}, false)
+	}
+
+	m.handlers = f.handler
+	m.ret(value)
+	return nil
+}
+
+// raiseProc - `raise` primitive: raises non-continuable exception
+func raiseProc(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return m.raise(args[0], false)
+}
+
+// raiseContinuable - `raise-continuable` primitive: raises exception,
+// the value of the handler is returned to the raise
+func raiseContinuable(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return m.raise(args[0], true)
+}
+
+// withExceptionHandler - `with-exception-handler` primitive:
+// (with-exception-handler handler thunk) calls the thunk with
+// the handler installed for the extent of the call
+func withExceptionHandler(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return err
+	}
+
+	if _, ok := args[0].(types.Callable); !ok {
+		return fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	m.push(handlersFrame{saved: m.handlers})
+	m.handlers = &handler{proc: args[0], next: m.handlers}
+	return m.apply(args[1], nil)
+}
+
+// handlersFrame - restores handlers on return
+type handlersFrame struct {
+	saved *handler
+}
+
+func (f handlersFrame) resume(m *machine, value types.Object) error {
+	m.handlers = f.saved
+	m.ret(value)
+	return nil
+}
+
+// guardian - handler installed by guard
+type guardian struct {
+	name    string
+	clauses []*data.AST
+	ctx     *data.Context
+	// k - continuation of the guard
+	k *Continuation
+}
+
+// guard - (guard (var clause...) body...) evaluates the body with
+// the handler, which moves to the guard's continuation and evaluates
+// the clauses there like cond ones with var bound to the raised
+// object. When none of them applies, the object is raised again
+// with raise-continuable within the dynamic environment of the
+// original raise
+func guard(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 3 {
+		return fmt.Errorf("%w: guard expects (var clause...) and body", errscm.ErrBadSyntax)
+	}
+
+	spec := ast.Subtrees[1]
+	if !spec.IsForm() || len(spec.Subtrees) == 0 || spec.Subtrees[0].Kind != data.VariableRef {
+		return fmt.Errorf("%w: guard expects (var clause...)", errscm.ErrBadSyntax)
+	}
+
+	g := &guardian{
+		name:    spec.Subtrees[0].Identifier(),
+		clauses: spec.Subtrees[1:],
+		ctx:     ctx,
+		k:       m.capture(),
+	}
+
+	m.push(handlersFrame{saved: m.handlers})
+	m.handlers = &handler{proc: control(g.handle), next: m.handlers}
+	return sequence(m, ast.Subtrees[2:], ctx)
+}
+
+// handle - handler procedure of the guard
+func (g *guardian) handle(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	obj := args[0]
+	raised := m.capture()
+	return m.jump(g.k, func(m *machine) error {
+		scope := g.ctx.Spawn()
+		scope.Define(g.name, obj)
+
+		return condClause(m, g.clauses, scope, func(m *machine) error {
+			return m.jump(raised, func(m *machine) error {
+				return m.raise(obj, true)
+			})
+		})
+	})
+}
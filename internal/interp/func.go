@@ -5,22 +5,44 @@ import (
 
 	"github.com/Vallghall/gopherscm/internal/core/types"
 	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
 )
 
+// Param - optional or keyword parameter with its default value
+// expression, evaluated when the argument is not provided
+type Param struct {
+	Name    string
+	Default *data.AST
+}
+
 // Func - ast, evaluated as function body
 type Func struct {
 	*data.AST
+	// Name - name the function is defined with, used in error messages
+	Name   string
 	Params []string
+	// Optionals - positional parameters following the required ones,
+	// defined with lambda* after the #!optional marker
+	Optionals []Param
+	// Keys - named parameters passed as `#:name value`,
+	// defined with lambda* after the #!key marker
+	Keys []Param
+	// Rest - parameter bound to the list of extra arguments,
+	// empty for functions with fixed arity
+	Rest string
 }
 
-// Func - create function from AST subtrees
-func NewFunc(ast *data.AST, body []*data.AST) *Func {
+// NewFunc - create function closed over the given context
+// with the body of AST subtrees
+func NewFunc(ctx *data.Context, params []string, rest string, body []*data.AST) *Func {
 	return &Func{
 		AST: &data.AST{
-			Ctx:      ast.Ctx.Spawn(),
+			Ctx:      ctx,
 			Kind:     data.Function,
 			Subtrees: body,
 		},
+		Params: params,
+		Rest:   rest,
 	}
 }
 
@@ -29,29 +51,203 @@ func (f *Func) Value() any {
 	return "lambda" // TODO: improve it
 }
 
+func (f *Func) String() string {
+	return fmt.Sprintf("#<procedure %s>", f.name())
+}
+
+// Arities - types.Introspectable interface implementation
+func (f *Func) Arities() []types.Arity {
+	return []types.Arity{f.arity()}
+}
+
+// arity - range of argument counts the function accepts,
+// every keyword argument counts as two: the keyword and the value
+func (f *Func) arity() types.Arity {
+	a := types.Arity{
+		Min: len(f.Params),
+		Max: len(f.Params) + len(f.Optionals) + 2*len(f.Keys),
+	}
+
+	if f.Rest != "" {
+		a.Max = -1
+	}
+
+	return a
+}
+
 // Call - types.Callable interface implementation
 // Binds given arguments to parameter list and evaluates the Func
-func (f *Func) Call(args ...types.Object) (result types.Object, err error) {
-	if len(args) != len(f.Params) {
-		return nil, fmt.Errorf(
-			"not enough arguments:\nexpected %d\ngot: %d",
-			len(args),
-			len(f.Params),
-		)
+func (f *Func) Call(args ...types.Object) (types.Object, error) {
+	return callProc(f, args, nil)
+}
+
+// applyFunc - binds given arguments to parameter list within
+// a new scope spawned for the call and evaluates the body there,
+// the last expression of the body is in tail position
+func applyFunc(m *machine, f *Func, args []types.Object) error {
+	scope := f.Ctx.Spawn()
+	pending, err := f.bindTo(scope, args)
+	if err != nil {
+		return err
+	}
+
+	return bindDefaults(m, scope, pending, f.Subtrees)
+}
+
+// bindTo - binds given arguments to parameter list within the context,
+// extra arguments are bound to the rest parameter as a list.
+// Returns parameters left to bind to their default values
+func (f *Func) bindTo(ctx *data.Context, args []types.Object) ([]Param, error) {
+	if a := f.arity(); !a.Accepts(len(args)) {
+		return nil, arityError(f.name(), a, len(args))
 	}
 
-	// define function params within the current context
 	for i, key := range f.Params {
-		f.Ctx.Set(key, args[i])
+		ctx.Define(key, args[i])
+	}
+
+	if len(f.Optionals) > 0 || len(f.Keys) > 0 {
+		return f.bindExtended(ctx, args[len(f.Params):])
+	}
+
+	if f.Rest != "" {
+		ctx.Define(f.Rest, types.List(args[len(f.Params):]...))
+	}
+
+	return nil, nil
+}
+
+// bindExtended - binds optional, keyword and rest parameters
+// of lambda* functions. Optional parameters take arguments
+// positionally until a keyword argument is met, keyword parameters
+// take values following their keywords, the rest parameter takes
+// all the arguments after the optional ones. Parameters without
+// arguments and default values are bound to #f, the ones with
+// default values are returned to be bound from left to right
+// in the scope of the preceding parameters
+func (f *Func) bindExtended(ctx *data.Context, args []types.Object) ([]Param, error) {
+	var pending []Param
+	for _, opt := range f.Optionals {
+		if len(args) > 0 && !(len(f.Keys) > 0 && isKeyword(args[0])) {
+			ctx.Define(opt.Name, args[0])
+			args = args[1:]
+			continue
+		}
+
+		pending = bindDefault(ctx, opt, pending)
+	}
+
+	rest := args
+	named := make(map[string]types.Object)
+	if len(f.Keys) > 0 {
+		for len(args) > 0 && isKeyword(args[0]) {
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%w: %s got keyword %v without value", errscm.ErrUnexpectedNumberOfArguments, f.name(), args[0])
+			}
+
+			named[string(args[0].(types.Keyword))] = args[1]
+			args = args[2:]
+		}
+
+		if f.Rest == "" && len(args) > 0 {
+			return nil, fmt.Errorf("%w: %s got unexpected argument %v", errscm.ErrUnexpectedNumberOfArguments, f.name(), args[0])
+		}
 	}
 
-	for _, expr := range f.Subtrees {
-		expr.Ctx = f.Ctx // enforce function ctx onto its body expressions
-		result, err = Eval(expr)
-		if err != nil {
-			return nil, err
+	for _, key := range f.Keys {
+		value, ok := named[key.Name]
+		if !ok {
+			pending = bindDefault(ctx, key, pending)
+			continue
 		}
+
+		delete(named, key.Name)
+		ctx.Define(key.Name, value)
+	}
+
+	if f.Rest == "" {
+		for name := range named {
+			return nil, fmt.Errorf("%w: %s got #:%s", errscm.ErrUnknownKeyword, f.name(), name)
+		}
+
+		return pending, nil
+	}
+
+	ctx.Define(f.Rest, types.List(rest...))
+	return pending, nil
+}
+
+// bindDefault - binds parameter without default to #f,
+// the one with default is added to the pending ones
+func bindDefault(ctx *data.Context, p Param, pending []Param) []Param {
+	if p.Default == nil {
+		ctx.Define(p.Name, types.Boolean(false))
+		return pending
+	}
+
+	return append(pending, p)
+}
+
+// bindDefaults - evaluates default values of the pending parameters
+// one by one and binds them, then evaluates the body
+func bindDefaults(m *machine, scope *data.Context, pending []Param, body []*data.AST) error {
+	if len(pending) == 0 {
+		return sequence(m, body, scope)
+	}
+
+	m.push(defaultFrame{scope: scope, pending: pending, body: body})
+	m.eval(pending[0].Default, scope)
+	return nil
+}
+
+// defaultFrame - binds the default value of the first pending parameter
+type defaultFrame struct {
+	scope   *data.Context
+	pending []Param
+	body    []*data.AST
+}
+
+func (f defaultFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	f.scope.Define(f.pending[0].Name, value)
+	return bindDefaults(m, f.scope, f.pending[1:], f.body)
+}
+
+// name - returns function name for error messages
+func (f *Func) name() string {
+	if f.Name == "" {
+		return "anonymous lambda"
+	}
+
+	return f.Name
+}
+
+// isKeyword - reports whether the object is a keyword
+func isKeyword(obj types.Object) bool {
+	_, ok := obj.(types.Keyword)
+	return ok
+}
+
+// arityError - reports procedure called with the wrong number of arguments
+func arityError(name string, a types.Arity, got int) error {
+	switch {
+	case a.Max < 0:
+		return fmt.Errorf(
+			"%w: %s expected at least %d args, got %d",
+			errscm.ErrUnexpectedNumberOfArguments, name, a.Min, got,
+		)
+	case a.Min == a.Max:
+		return fmt.Errorf(
+			"%w: %s expected %d args, got %d",
+			errscm.ErrUnexpectedNumberOfArguments, name, a.Min, got,
+		)
 	}
 
-	return result, nil
+	return fmt.Errorf(
+		"%w: %s expected from %d to %d args, got %d",
+		errscm.ErrUnexpectedNumberOfArguments, name, a.Min, a.Max, got,
+	)
 }
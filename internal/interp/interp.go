@@ -5,19 +5,23 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/Vallghall/gopherscm/internal/core/bytevectors"
 	"github.com/Vallghall/gopherscm/internal/core/types"
 	"github.com/Vallghall/gopherscm/internal/data"
 	"github.com/Vallghall/gopherscm/internal/errscm"
 )
 
-// Walk - evaluate whole AST to a single value
+// Walk - evaluate whole AST to a single value, context of the
+// root becomes the interaction environment
 func Walk(ast *data.AST) (res types.Object, err error) {
 	if ast.Kind != data.Root {
 		return nil, errors.New("available for root node only")
 	}
 
+	interaction = ast.Ctx
+
 	for _, st := range ast.Subtrees {
-		res, err = Eval(st)
+		res, err = Eval(st, ast.Ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -26,37 +30,171 @@ func Walk(ast *data.AST) (res types.Object, err error) {
 	return
 }
 
-// Eval - evaluates expression subtree based on its kind
-func Eval(ast *data.AST) (types.Object, error) {
+// Eval - evaluates top-level expression within the given context,
+// errors are reported at the position of the expression they
+// occurred in. The context becomes top-level one of a program
+func Eval(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	ctx.SetTopLevel()
+	m := newMachine(topLevel, nil)
+	m.eval(ast, ctx)
+	res, err := m.run()
+	if err != nil {
+		return nil, m.located(err)
+	}
+
+	return res, nil
+}
+
+// evalStep - evaluates expression subtree within the given context
+// based on its kind. Atoms return their values right away, forms
+// push frames waiting for the values of their subexpressions and
+// continue with them, expressions in tail position are evaluated
+// without pushing a frame. Special form keywords are resolved
+// through the context: a variable or a macro bound to the keyword
+// shadows the builtin form
+func evalStep(m *machine, ast *data.AST, ctx *data.Context) error {
+	if ast.Kind != data.CallExpr && shadowed(ast, ctx) {
+		return call(m, ast, ctx)
+	}
+
 	switch ast.Kind {
 	case data.CallExpr:
-		return call(ast)
+		return call(m, ast, ctx)
 	case data.VariableRef:
-		return getVar(ast)
-	case data.DefineExpr:
-		return define(ast)
+		return returning(m)(getVar(ast, ctx))
+	case data.DefineExpr, data.DefineStarExpr:
+		return define(m, ast, ctx)
+	case data.SetExpr:
+		return set(m, ast, ctx)
+	case data.IfExpr:
+		return ifExpr(m, ast, ctx)
+	case data.CondExpr:
+		return cond(m, ast, ctx)
+	case data.BeginExpr:
+		return sequence(m, ast.Subtrees[1:], ctx)
+	case data.DoExpr:
+		return do(m, ast, ctx)
+	case data.AndExpr:
+		return and(m, ast, ctx)
+	case data.OrExpr:
+		return or(m, ast, ctx)
+	case data.LambdaExpr, data.LambdaStarExpr:
+		return returning(m)(lambda(ast, ctx))
+	case data.CaseLambdaExpr:
+		return returning(m)(caseLambda(ast, ctx))
+	case data.LetValuesExpr, data.LetStarValuesExpr:
+		return letValues(m, ast, ctx)
+	case data.DefineValuesExpr:
+		return defineValues(m, ast, ctx)
+	case data.ReceiveExpr:
+		return receive(m, ast, ctx)
+	case data.GuardExpr:
+		return guard(m, ast, ctx)
+	case data.DelayExpr, data.DelayForceExpr:
+		return returning(m)(delay(ast, ctx))
+	case data.ParameterizeExpr:
+		return parameterize(m, ast, ctx)
+	case data.StreamConsExpr:
+		return returning(m)(streamCons(ast, ctx))
+	case data.DefineSyntaxExpr:
+		return defineSyntax(m, ast, ctx)
+	case data.SyntaxRulesExpr:
+		return returning(m)(syntaxRules(ast, ctx))
+	case data.QuoteExpr:
+		return returning(m)(quote(ast))
+	case data.DefineRecordTypeExpr:
+		return returning(m)(defineRecordType(ast, ctx))
+	case data.DefineLibraryExpr:
+		return defineLibrary(m, ast, ctx)
+	case data.ImportExpr:
+		return returning(m)(importExpr(ast, ctx))
+	case data.IncludeExpr:
+		return includeExpr(m, ast, ctx)
+	case data.CondExpandExpr:
+		return condExpandExpr(m, ast, ctx)
+	case data.LetSyntaxExpr, data.LetrecSyntaxExpr:
+		return letSyntax(m, ast, ctx)
+	case data.LetExpr:
+		return let(m, ast, ctx)
+	case data.LetStarExpr:
+		return letStar(m, ast, ctx)
+	case data.LetrecExpr, data.LetrecStarExpr:
+		return letrec(m, ast, ctx)
 	case data.Literal:
-		return evalLiteral(ast)
+		return returning(m)(evalLiteral(ast))
+	case data.Vector:
+		return returning(m)(datum(ast))
+	case data.Bytevector:
+		return returning(m)(evalBytevector(ast))
 	}
 
-	return nil, errscm.ErrUnsupported
+	return errscm.ErrUnsupported
+}
+
+// returning - helper returning values of expressions
+// evaluated right away
+func returning(m *machine) func(types.Object, error) error {
+	return func(value types.Object, err error) error {
+		if err != nil {
+			return err
+		}
+
+		m.ret(value)
+		return nil
+	}
+}
+
+// sequence - evaluates the expressions one by one within the given
+// context, the last one is in tail position
+func sequence(m *machine, body []*data.AST, ctx *data.Context) error {
+	switch len(body) {
+	case 0:
+		m.ret(nil)
+	case 1:
+		m.eval(body[0], ctx)
+	default:
+		m.push(sequenceFrame{body: body[1:], ctx: ctx})
+		m.eval(body[0], ctx)
+	}
+
+	return nil
+}
+
+// sequenceFrame - continues the sequence with the rest
+// of its expressions
+type sequenceFrame struct {
+	body []*data.AST
+	ctx  *data.Context
+}
+
+func (f sequenceFrame) resume(m *machine, _ types.Object) error {
+	return sequence(m, f.body, f.ctx)
 }
 
 // evalLiteral - wrapping literal's token value into
 // a type that implements types.Object
 func evalLiteral(ast *data.AST) (types.Object, error) {
-	switch ast.Token.Type() {
+	return literal(ast.Token)
+}
+
+// literal - converts literal token into an object
+func literal(t *data.Token) (types.Object, error) {
+	switch t.Type() {
 	case data.String:
-		return types.String(ast.Token.Value()), nil
+		return types.String(t.Value()), nil
+	case data.Boolean:
+		return types.Boolean(t.Value() == "t"), nil
+	case data.Keyword:
+		return types.Keyword(t.Value()), nil
 	case data.Int:
-		num, err := strconv.ParseInt(ast.Token.Value(), 10, 64)
+		num, err := strconv.ParseInt(t.Value(), 10, 64)
 		if err != nil {
 			return nil, err
 		}
 
 		return types.NumberFrom(num), nil
 	case data.Float:
-		num, err := strconv.ParseFloat(ast.Token.Value(), 64)
+		num, err := strconv.ParseFloat(t.Value(), 64)
 		if err != nil {
 			return nil, err
 		}
@@ -68,80 +206,344 @@ func evalLiteral(ast *data.AST) (types.Object, error) {
 	return nil, errors.New("unimplemented")
 }
 
+// evalBytevector - builds bytevector out of literal's elements,
+// which all must be integer literals in range [0, 255]
+func evalBytevector(ast *data.AST) (types.Object, error) {
+	bs := make([]byte, len(ast.Subtrees))
+	for i, st := range ast.Subtrees {
+		if st.Kind != data.Literal {
+			return nil, fmt.Errorf("%w: expected byte, got %v", errscm.ErrUnexpectedType, st.Identifier())
+		}
+
+		obj, err := evalLiteral(st)
+		if err != nil {
+			return nil, err
+		}
+
+		if bs[i], err = bytevectors.Byte(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return types.NewBytevector(bs), nil
+}
+
 // getVar - variable lookup
-func getVar(ast *data.AST) (types.Object, error) {
-	def, ok := ast.Ctx.FindDef(ast.Identifier())
+func getVar(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	def, ok := lookup(ast, ctx)
 	if !ok {
-		return nil, fmt.Errorf(`"%v" is not defined`, ast.Identifier())
+		return nil, fmt.Errorf(`%w: "%v" is not defined`, errscm.ErrUnboundVariable, ast.Name())
+	}
+
+	switch def.(type) {
+	case unassigned:
+		return nil, fmt.Errorf(`%w: "%v"`, errscm.ErrUseBeforeInit, ast.Name())
+	case *Macro:
+		return nil, fmt.Errorf(`%w: syntax keyword "%v" used as variable`, errscm.ErrBadSyntax, ast.Name())
 	}
 
 	return def, nil
 }
 
-// call - asserts that the object called is types.Callable,
-// evaluates its list of arguments and calls the function with
-// the evaluated arguments
-func call(ast *data.AST) (types.Object, error) {
-	def, ok := ast.Ctx.FindDef(ast.Identifier())
-	if !ok {
-		return nil, fmt.Errorf(`"%v" is not defined`, ast.Identifier())
+// shadowed - reports whether the keyword heading the special form
+// is bound within the context, builtin forms are not bound anywhere
+func shadowed(ast *data.AST, ctx *data.Context) bool {
+	if ast.Head() == "" {
+		return false
 	}
 
-	fun, ok := def.(types.Callable)
-	if !ok {
-		return nil, fmt.Errorf(`"%v" is not a function`, ast.Identifier())
+	_, bound := lookup(ast.Subtrees[0], ctx)
+	return bound
+}
+
+// call - evaluates the head of the form and its list of arguments,
+// then applies the function to the evaluated arguments
+func call(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) == 0 {
+		return fmt.Errorf("%w: empty combination ()", errscm.ErrBadSyntax)
 	}
 
-	var args []types.Object
-	for _, st := range ast.Subtrees {
-		st.Ctx = ast.Ctx // function context enforcement
-		arg, err := Eval(st)
-		if err != nil {
-			return nil, err
-		}
+	if mac, ok := macro(ast.Subtrees[0], ctx); ok {
+		return mac.use(m, ast, ctx)
+	}
+
+	m.push(argFrame{ast: ast, ctx: ctx})
+	m.eval(ast.Subtrees[0], ctx)
+	return nil
+}
 
-		args = append(args, arg)
+// argFrame - collects values of the combination's elements,
+// the function goes first
+type argFrame struct {
+	ast    *data.AST
+	ctx    *data.Context
+	values []types.Object
+}
+
+func (f argFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	// the frame may be resumed again, so its values are copied
+	values := append(f.values[:len(f.values):len(f.values)], value)
+	if len(values) < len(f.ast.Subtrees) {
+		m.push(argFrame{ast: f.ast, ctx: f.ctx, values: values})
+		m.eval(f.ast.Subtrees[len(values)], f.ctx)
+		return nil
 	}
 
-	return fun.Call(args...)
+	m.form = f.ast
+	return m.apply(values[0], values[1:])
 }
 
-// define - handles variable and function definitions
-func define(ast *data.AST) (types.Object, error) {
-	id := ast.Subtrees[0]
+// define - handles variable and function definitions,
+// define* functions accept extended parameter lists
+func define(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 2 {
+		return fmt.Errorf("%w: missing definition name", errscm.ErrTooLittleArguments)
+	}
+
+	id := ast.Subtrees[1]
 
 	if id.Kind == data.VariableRef {
-		if len(ast.Subtrees) != 2 {
-			return nil, fmt.Errorf("%w: expected 2 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees))
+		if len(ast.Subtrees) != 3 {
+			return fmt.Errorf("%w: expected 2 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
 		}
 
-		def := ast.Subtrees[1]
-		value, err := Eval(def)
-		if err != nil {
-			return nil, err
+		m.push(defineFrame{name: id.Identifier(), ctx: ctx})
+		m.eval(ast.Subtrees[2], ctx)
+		return nil
+	}
+
+	if id.IsForm() && id.Head() != "" {
+		if len(ast.Subtrees) < 3 {
+			return fmt.Errorf("%w: missing function body", errscm.ErrTooLittleArguments)
+		}
+
+		elems, restParam, ok := id.Split()
+		if !ok {
+			return fmt.Errorf("%w: misplaced dot in parameter list", errscm.ErrBadSyntax)
+		}
+
+		fn := NewFunc(ctx, nil, "", ast.Subtrees[2:])
+		if err := paramList(fn, elems[1:], restParam, ast.Kind == data.DefineStarExpr); err != nil {
+			return err
+		}
+
+		fn.Name = id.Subtrees[0].Identifier()
+		ctx.Define(fn.Name, fn)
+		m.ret(nil)
+		return nil
+	}
+
+	return fmt.Errorf("%w: invalid definition name", errscm.ErrBadSyntax)
+}
+
+// defineFrame - defines variable with the value
+type defineFrame struct {
+	name string
+	ctx  *data.Context
+}
+
+func (f defineFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	nameProcedure(value, f.name)
+	f.ctx.Define(f.name, value)
+	m.ret(nil)
+	return nil
+}
+
+// nameProcedure - names anonymous procedure after the variable
+// it is defined with
+func nameProcedure(obj types.Object, name string) {
+	switch fn := obj.(type) {
+	case *Func:
+		if fn.Name == "" {
+			fn.Name = name
 		}
+	case *CaseLambda:
+		if fn.Name == "" {
+			fn.Name = name
+		}
+	}
+}
+
+// set - assigns new value to the nearest existing binding of the variable
+func set(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) != 3 {
+		return fmt.Errorf("%w: expected 2 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
+	}
+
+	id := ast.Subtrees[1]
+	if id.Kind != data.VariableRef {
+		return fmt.Errorf("%w: set! expects identifier", errscm.ErrBadSyntax)
+	}
+
+	m.push(setFrame{id: id, ctx: ctx})
+	m.eval(ast.Subtrees[2], ctx)
+	return nil
+}
+
+// setFrame - assigns the value to the variable
+type setFrame struct {
+	id  *data.AST
+	ctx *data.Context
+}
+
+func (f setFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	if !assign(f.id, f.ctx, value) {
+		return fmt.Errorf(`%w: "%v" is not defined`, errscm.ErrUnboundVariable, f.id.Name())
+	}
+
+	m.ret(nil)
+	return nil
+}
+
+// lambda - creates anonymous function closed over the current context.
+// Parameters are either a list of identifiers, possibly improper with
+// the rest parameter after the dot, or a single identifier bound to
+// the list of all the arguments. lambda* accepts extended parameter lists
+func lambda(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	if len(ast.Subtrees) < 3 {
+		return nil, fmt.Errorf("%w: missing function body", errscm.ErrTooLittleArguments)
+	}
+
+	return newFunc(ast.Subtrees[1], ast.Subtrees[2:], ctx, ast.Kind == data.LambdaStarExpr)
+}
+
+// newFunc - creates function out of the parameter list and body
+func newFunc(formals *data.AST, body []*data.AST, ctx *data.Context, extended bool) (*Func, error) {
+	if formals.Kind == data.VariableRef {
+		return NewFunc(ctx, nil, formals.Identifier(), body), nil
+	}
+
+	if !formals.IsForm() {
+		return nil, fmt.Errorf("%w: lambda expects parameter list", errscm.ErrBadSyntax)
+	}
 
-		ast.Ctx.Set(id.Identifier(), value)
-		return nil, nil
+	elems, restParam, ok := formals.Split()
+	if !ok {
+		return nil, fmt.Errorf("%w: misplaced dot in parameter list", errscm.ErrBadSyntax)
 	}
 
-	if id.Kind == data.CallExpr {
-		if len(ast.Subtrees) < 2 {
-			return nil, fmt.Errorf("%w: missing function body", errscm.ErrTooLittleArguments)
+	fn := NewFunc(ctx, nil, "", body)
+	if err := paramList(fn, elems, restParam, extended); err != nil {
+		return nil, err
+	}
+
+	return fn, nil
+}
+
+// parameter list sections of lambda*
+const (
+	required = iota
+	optional
+	key
+	rest
+)
+
+// markers - parameter list section markers of lambda*
+var markers = map[string]int{
+	"#!optional": optional,
+	"#!key":      key,
+	"#!rest":     rest,
+}
+
+// paramList - fills parameters of the function from the parameter
+// list elements and the rest parameter after the dot, if there is one.
+// Extended parameter lists of lambda* may be split with the
+// #!optional, #!key and #!rest markers (or #:optional, #:key, #:rest),
+// optional and keyword parameters are either identifiers or
+// (name default) lists
+func paramList(fn *Func, asts []*data.AST, restParam *data.AST, extended bool) error {
+	section := required
+	for i, param := range asts {
+		if next, ok := marker(param); ok && extended {
+			if next <= section {
+				return fmt.Errorf("%w: misplaced %s marker", errscm.ErrBadSyntax, param.Identifier())
+			}
+
+			section = next
+			if section == rest {
+				if i != len(asts)-2 || restParam != nil {
+					return fmt.Errorf("%w: #!rest must be followed by the last parameter", errscm.ErrBadSyntax)
+				}
+
+				restParam = asts[i+1]
+				break
+			}
+
+			continue
 		}
 
-		fn := NewFunc(id, ast.Subtrees[1:])
-		for _, param := range id.Subtrees {
-			if param.Kind == data.VariableRef {
-				fn.Params = append(fn.Params, param.Identifier())
-				continue
+		if section == required {
+			if param.Kind != data.VariableRef {
+				return fmt.Errorf("%w: %s is not a valid identifier", errscm.ErrBadSyntax, param.Identifier())
 			}
 
-			return nil, fmt.Errorf("%s is not a valid identifier", param.Identifier())
+			fn.Params = append(fn.Params, param.Identifier())
+			continue
 		}
 
-		ast.Ctx.Set(id.Identifier(), fn)
+		p, err := defaultParam(param)
+		if err != nil {
+			return err
+		}
+
+		if section == optional {
+			fn.Optionals = append(fn.Optionals, p)
+		} else {
+			fn.Keys = append(fn.Keys, p)
+		}
+	}
+
+	if restParam == nil {
+		return nil
+	}
+
+	if restParam.Kind != data.VariableRef {
+		return fmt.Errorf("%w: %s is not a valid identifier", errscm.ErrBadSyntax, restParam.Identifier())
+	}
+
+	fn.Rest = restParam.Identifier()
+	return nil
+}
+
+// marker - recognizes lambda* section markers
+func marker(ast *data.AST) (int, bool) {
+	switch {
+	case ast.Kind == data.VariableRef:
+		section, ok := markers[ast.Name()]
+		return section, ok
+	case ast.Kind == data.Literal && ast.Token.Type() == data.Keyword:
+		section, ok := markers["#!"+ast.Identifier()]
+		return section, ok
+	}
+
+	return 0, false
+}
+
+// defaultParam - parses optional or keyword parameter spec:
+// name or (name default)
+func defaultParam(ast *data.AST) (Param, error) {
+	if ast.Kind == data.VariableRef {
+		return Param{Name: ast.Identifier()}, nil
+	}
+
+	if !ast.IsForm() || len(ast.Subtrees) != 2 || ast.Subtrees[0].Kind != data.VariableRef {
+		return Param{}, fmt.Errorf("%w: parameter with default must be (name default)", errscm.ErrBadSyntax)
 	}
 
-	return nil, nil
+	return Param{
+		Name:    ast.Subtrees[0].Identifier(),
+		Default: ast.Subtrees[1],
+	}, nil
 }
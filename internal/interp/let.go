@@ -0,0 +1,252 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// unassigned - placeholder bound to letrec variables
+// until their initialisers are evaluated
+type unassigned struct{}
+
+// Value - types.Object interface implementation
+func (unassigned) Value() any {
+	return nil
+}
+
+// binding - variable name with its initialiser
+type binding struct {
+	name string
+	init *data.AST
+}
+
+// let - evaluates initialisers within the current context and
+// binds them within a new one, where the body is evaluated.
+// Dispatches to namedLet for (let name ((var init) ...) body...)
+func let(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) > 1 && ast.Subtrees[1].Kind == data.VariableRef {
+		return namedLet(m, ast, ctx)
+	}
+
+	bs, body, err := letParts(ast, 1)
+	if err != nil {
+		return err
+	}
+
+	return evalAll(m, inits(bs), ctx, func(m *machine, values []types.Object) error {
+		scope := ctx.Spawn()
+		for i, b := range bs {
+			scope.Define(b.name, values[i])
+		}
+
+		return sequence(m, body, scope)
+	})
+}
+
+// namedLet - binds loop procedure with the bound variables as its
+// parameters and the let body as its body, then calls it with the
+// initial values
+func namedLet(m *machine, ast *data.AST, ctx *data.Context) error {
+	bs, body, err := letParts(ast, 2)
+	if err != nil {
+		return err
+	}
+
+	params := make([]string, len(bs))
+	for i, b := range bs {
+		params[i] = b.name
+	}
+
+	return evalAll(m, inits(bs), ctx, func(m *machine, args []types.Object) error {
+		scope := ctx.Spawn()
+		loop := NewFunc(scope, params, "", body)
+		loop.Name = ast.Subtrees[1].Identifier()
+		scope.Define(loop.Name, loop)
+
+		return m.apply(loop, args)
+	})
+}
+
+// letStar - evaluates each initialiser within the scope of
+// the previous bindings and binds it within a new scope
+func letStar(m *machine, ast *data.AST, ctx *data.Context) error {
+	bs, body, err := letParts(ast, 1)
+	if err != nil {
+		return err
+	}
+
+	return letStarBinding(m, bs, body, ctx)
+}
+
+// letStarBinding - evaluates initialiser of the first binding,
+// the body is evaluated after the last one
+func letStarBinding(m *machine, bs []binding, body []*data.AST, scope *data.Context) error {
+	if len(bs) == 0 {
+		// the body gets its own scope for internal definitions
+		return sequence(m, body, scope.Spawn())
+	}
+
+	m.push(letStarFrame{bs: bs, body: body, scope: scope})
+	m.eval(bs[0].init, scope)
+	return nil
+}
+
+// letStarFrame - binds the first binding within a new scope
+type letStarFrame struct {
+	bs    []binding
+	body  []*data.AST
+	scope *data.Context
+}
+
+func (f letStarFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	scope := f.scope.Spawn()
+	scope.Define(f.bs[0].name, value)
+	return letStarBinding(m, f.bs[1:], f.body, scope)
+}
+
+// letrec - binds variables within a new scope, where their
+// initialisers are evaluated, so they can refer to each other.
+// letrec assigns variables after all the initialisers are
+// evaluated, letrec* assigns each one right after its evaluation.
+// Referring to a variable before its assignment is an error
+func letrec(m *machine, ast *data.AST, ctx *data.Context) error {
+	bs, body, err := letParts(ast, 1)
+	if err != nil {
+		return err
+	}
+
+	scope := ctx.Spawn()
+	for _, b := range bs {
+		scope.Define(b.name, unassigned{})
+	}
+
+	if ast.Kind == data.LetrecStarExpr {
+		return letrecStarBinding(m, bs, body, scope)
+	}
+
+	return evalAll(m, inits(bs), scope, func(m *machine, values []types.Object) error {
+		for i, b := range bs {
+			scope.Define(b.name, values[i])
+		}
+
+		return sequence(m, body, scope)
+	})
+}
+
+// letrecStarBinding - evaluates initialiser of the first binding,
+// the body is evaluated after the last one
+func letrecStarBinding(m *machine, bs []binding, body []*data.AST, scope *data.Context) error {
+	if len(bs) == 0 {
+		return sequence(m, body, scope)
+	}
+
+	m.push(letrecStarFrame{bs: bs, body: body, scope: scope})
+	m.eval(bs[0].init, scope)
+	return nil
+}
+
+// letrecStarFrame - assigns the first binding
+type letrecStarFrame struct {
+	bs    []binding
+	body  []*data.AST
+	scope *data.Context
+}
+
+func (f letrecStarFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	f.scope.Define(f.bs[0].name, value)
+	return letrecStarBinding(m, f.bs[1:], f.body, f.scope)
+}
+
+// letParts - splits let form into bindings and body, where bindings
+// list is located at the given index. Each variable may be bound only
+// once, except for let* which binds them within nested scopes
+func letParts(ast *data.AST, at int) ([]binding, []*data.AST, error) {
+	if len(ast.Subtrees) < at+2 {
+		return nil, nil, fmt.Errorf("%w: missing %s body", errscm.ErrTooLittleArguments, ast.Head())
+	}
+
+	list := ast.Subtrees[at]
+	if !list.IsForm() {
+		return nil, nil, fmt.Errorf("%w: %s expects binding list", errscm.ErrBadSyntax, ast.Head())
+	}
+
+	bs := make([]binding, len(list.Subtrees))
+	seen := make(map[string]bool, len(list.Subtrees))
+	for i, b := range list.Subtrees {
+		if !b.IsForm() || len(b.Subtrees) != 2 || b.Subtrees[0].Kind != data.VariableRef {
+			return nil, nil, fmt.Errorf("%w: %s binding must be (name value)", errscm.ErrBadSyntax, ast.Head())
+		}
+
+		name := b.Subtrees[0].Identifier()
+		if seen[name] && ast.Head() != "let*" {
+			return nil, nil, fmt.Errorf("%w: %s binds %s more than once", errscm.ErrBadSyntax, ast.Head(), b.Subtrees[0].Name())
+		}
+
+		seen[name] = true
+
+		bs[i] = binding{
+			name: name,
+			init: b.Subtrees[1],
+		}
+	}
+
+	return bs, ast.Subtrees[at+1:], nil
+}
+
+// inits - initialisers of the bindings
+func inits(bs []binding) []*data.AST {
+	exprs := make([]*data.AST, len(bs))
+	for i, b := range bs {
+		exprs[i] = b.init
+	}
+
+	return exprs
+}
+
+// evalAll - evaluates expressions one by one within the given
+// context, then continues with their values
+func evalAll(m *machine, exprs []*data.AST, ctx *data.Context, then func(*machine, []types.Object) error) error {
+	if len(exprs) == 0 {
+		return then(m, nil)
+	}
+
+	m.push(evalAllFrame{exprs: exprs, ctx: ctx, then: then})
+	m.eval(exprs[0], ctx)
+	return nil
+}
+
+// evalAllFrame - collects values of the expressions
+type evalAllFrame struct {
+	exprs  []*data.AST
+	ctx    *data.Context
+	values []types.Object
+	then   func(*machine, []types.Object) error
+}
+
+func (f evalAllFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	// the frame may be resumed again, so its values are copied
+	values := append(f.values[:len(f.values):len(f.values)], value)
+	if len(values) == len(f.exprs) {
+		return f.then(m, values)
+	}
+
+	f.values = values
+	m.push(f)
+	m.eval(f.exprs[len(values)], f.ctx)
+	return nil
+}
@@ -0,0 +1,577 @@
+package interp
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/Vallghall/gopherscm/internal/parser"
+)
+
+func init() {
+	core.Register("scheme base", "features", control(featuresProc))
+}
+
+// features - feature identifiers recognized by cond-expand
+var features = []string{
+	"r7rs", "gopherscm", "srfi-9", "srfi-39", "srfi-41",
+	"srfi-69", "srfi-125", runtime.GOOS, runtime.GOARCH,
+}
+
+// Library - library defined by define-library or a builtin one.
+// Exported bindings are resolved within the library's own
+// top-level context, so the libraries do not collide on names
+type Library struct {
+	// ctx - top-level context of the library body
+	ctx *data.Context
+	// exports - internal names of the exported
+	// bindings keyed by their external names
+	exports map[string]string
+}
+
+// libraries - libraries defined so far, keyed by
+// the name parts joined with spaces
+var libraries = make(map[string]*Library)
+
+// findLibrary - returns library by its name, builtin libraries
+// are created out of the core definitions on the first use,
+// the other ones are loaded from the search path
+func findLibrary(name string) (*Library, error) {
+	if lib, ok := libraries[name]; ok {
+		return lib, nil
+	}
+
+	defs, ok := core.Library(name)
+	if !ok {
+		return loadLibrary(name)
+	}
+
+	lib := &Library{ctx: data.NewContext(defs), exports: make(map[string]string, len(defs))}
+	for def := range defs {
+		lib.exports[def] = def
+	}
+
+	libraries[name] = lib
+	return lib, nil
+}
+
+// bindings - values of the exported bindings keyed by their external names
+func (lib *Library) bindings() (map[string]types.Object, error) {
+	defs := make(map[string]types.Object, len(lib.exports))
+	for external, internal := range lib.exports {
+		obj, ok := lib.ctx.FindDef(internal)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is exported but not defined", errscm.ErrUnboundVariable, internal)
+		}
+
+		defs[external] = obj
+	}
+
+	return defs, nil
+}
+
+// libraryName - converts library name (part ...) into
+// the key of the library, parts are identifiers or integers
+func libraryName(ast *data.AST) (string, error) {
+	if !ast.IsForm() || len(ast.Subtrees) == 0 {
+		return "", fmt.Errorf("%w: library name must be a list of identifiers and integers", errscm.ErrBadSyntax)
+	}
+
+	parts := make([]string, len(ast.Subtrees))
+	for i, part := range ast.Subtrees {
+		if part.Kind != data.VariableRef && (part.Kind != data.Literal || part.Token.Type() != data.Int) {
+			return "", fmt.Errorf("%w: library name must be a list of identifiers and integers", errscm.ErrBadSyntax)
+		}
+
+		parts[i] = part.Name()
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// defineLibrary - (define-library name declaration ...) evaluates the
+// library body within a new top-level context and registers the library
+// under the name once all of its exports are defined
+func defineLibrary(m *machine, ast *data.AST, ctx *data.Context) error {
+	if err := atTopLevel(ast, ctx); err != nil {
+		return err
+	}
+
+	if len(ast.Subtrees) < 2 {
+		return fmt.Errorf("%w: define-library expects library name", errscm.ErrTooLittleArguments)
+	}
+
+	name, err := libraryName(ast.Subtrees[1])
+	if err != nil {
+		return err
+	}
+
+	lib := &Library{
+		ctx:     data.NewContext(make(map[string]types.Object)),
+		exports: make(map[string]string),
+	}
+	lib.ctx.SetTopLevel()
+
+	body, err := lib.declarations(ast.Subtrees[2:])
+	if err != nil {
+		return err
+	}
+
+	m.push(funcFrame(func(m *machine, _ types.Object) error {
+		if _, err := lib.bindings(); err != nil {
+			return err
+		}
+
+		libraries[name] = lib
+		m.ret(nil)
+		return nil
+	}))
+
+	return sequence(m, body, lib.ctx)
+}
+
+// declarations - collects exports of the library declarations, returns
+// the forms to be evaluated within the library context in their order
+func (lib *Library) declarations(decls []*data.AST) ([]*data.AST, error) {
+	body := make([]*data.AST, 0)
+	for _, decl := range decls {
+		if !decl.IsForm() {
+			return nil, fmt.Errorf("%w: library declaration must be a form", errscm.ErrBadSyntax)
+		}
+
+		switch decl.Head() {
+		case "export":
+			if err := lib.export(decl.Subtrees[1:]); err != nil {
+				return nil, err
+			}
+		case "import":
+			body = append(body, decl)
+		case "begin":
+			body = append(body, decl.Subtrees[1:]...)
+		case "include":
+			forms, err := include(decl)
+			if err != nil {
+				return nil, err
+			}
+
+			body = append(body, forms...)
+		case "include-library-declarations", "cond-expand":
+			var (
+				forms []*data.AST
+				err   error
+			)
+
+			if decl.Head() == "cond-expand" {
+				forms, err = condExpand(decl, true)
+			} else {
+				forms, err = include(decl)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			nested, err := lib.declarations(forms)
+			if err != nil {
+				return nil, err
+			}
+
+			body = append(body, nested...)
+		default:
+			return nil, fmt.Errorf("%w: unknown library declaration %s", errscm.ErrBadSyntax, decl.Head())
+		}
+	}
+
+	return body, nil
+}
+
+// export - adds export specs, identifiers and (rename internal external)
+// forms, to the exported bindings of the library
+func (lib *Library) export(specs []*data.AST) error {
+	for _, spec := range specs {
+		switch {
+		case spec.Kind == data.VariableRef:
+			lib.exports[spec.Name()] = spec.Name()
+		case spec.Head() == "rename" && len(spec.Subtrees) == 3 && identifiers(spec.Subtrees[1:]):
+			lib.exports[spec.Subtrees[2].Name()] = spec.Subtrees[1].Name()
+		default:
+			return fmt.Errorf("%w: export spec must be identifier or (rename internal external)", errscm.ErrBadSyntax)
+		}
+	}
+
+	return nil
+}
+
+// importExpr - (import set ...) defines the bindings
+// provided by the import sets within the context. A program
+// importing libraries no longer sees the builtins it did not import
+func importExpr(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	if err := atTopLevel(ast, ctx); err != nil {
+		return nil, err
+	}
+
+	imported := make([]map[string]types.Object, 0, len(ast.Subtrees)-1)
+	for _, set := range ast.Subtrees[1:] {
+		defs, err := importSet(set)
+		if err != nil {
+			return nil, err
+		}
+
+		imported = append(imported, defs)
+	}
+
+	ctx.DropImplicit()
+	for _, defs := range imported {
+		for name, obj := range defs {
+			ctx.Define(name, obj)
+		}
+	}
+
+	return nil, nil
+}
+
+// atTopLevel - checks that the library form is evaluated at top level
+// of a program or a library, so code evaluated within restricted
+// environments or bodies can not import the bindings it was not given
+func atTopLevel(ast *data.AST, ctx *data.Context) error {
+	if !ctx.TopLevel() {
+		return fmt.Errorf("%w: %s is allowed at top level of a program or a library only", errscm.ErrBadSyntax, ast.Head())
+	}
+
+	return nil
+}
+
+// importSet - resolves the import set into the bindings it provides:
+// a library name or only, except, prefix and rename applied to
+// another import set
+func importSet(set *data.AST) (map[string]types.Object, error) {
+	switch set.Head() {
+	case "only", "except", "prefix", "rename":
+		if len(set.Subtrees) > 1 && set.Subtrees[1].IsForm() {
+			defs, err := importSet(set.Subtrees[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return modifyImport(set, defs)
+		}
+	}
+
+	name, err := libraryName(set)
+	if err != nil {
+		return nil, err
+	}
+
+	lib, err := findLibrary(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return lib.bindings()
+}
+
+// modifyImport - applies only, except, prefix or rename
+// import set to the bindings of the nested one
+func modifyImport(set *data.AST, defs map[string]types.Object) (map[string]types.Object, error) {
+	args := set.Subtrees[2:]
+	switch set.Head() {
+	case "only":
+		if !identifiers(args) {
+			return nil, fmt.Errorf("%w: only expects identifiers", errscm.ErrBadSyntax)
+		}
+
+		only := make(map[string]types.Object, len(args))
+		for _, id := range args {
+			obj, ok := defs[id.Name()]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s is not exported", errscm.ErrUnboundVariable, id.Name())
+			}
+
+			only[id.Name()] = obj
+		}
+
+		return only, nil
+	case "except":
+		if !identifiers(args) {
+			return nil, fmt.Errorf("%w: except expects identifiers", errscm.ErrBadSyntax)
+		}
+
+		for _, id := range args {
+			if _, ok := defs[id.Name()]; !ok {
+				return nil, fmt.Errorf("%w: %s is not exported", errscm.ErrUnboundVariable, id.Name())
+			}
+
+			delete(defs, id.Name())
+		}
+
+		return defs, nil
+	case "prefix":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%w: prefix expects identifier", errscm.ErrBadSyntax)
+		}
+
+		// prefixes like c: are lexed as keywords
+		var prefix string
+		switch {
+		case args[0].Kind == data.VariableRef:
+			prefix = args[0].Name()
+		case args[0].Kind == data.Literal && args[0].Token.Type() == data.Keyword:
+			prefix = args[0].Identifier() + ":"
+		default:
+			return nil, fmt.Errorf("%w: prefix expects identifier", errscm.ErrBadSyntax)
+		}
+
+		prefixed := make(map[string]types.Object, len(defs))
+		for name, obj := range defs {
+			prefixed[prefix+name] = obj
+		}
+
+		return prefixed, nil
+	}
+
+	// renames are applied all at once, so the names may be swapped
+	renamed := make(map[string]types.Object, len(args))
+	for _, spec := range args {
+		if !spec.IsForm() || len(spec.Subtrees) != 2 || !identifiers(spec.Subtrees) {
+			return nil, fmt.Errorf("%w: rename expects (from to) pairs", errscm.ErrBadSyntax)
+		}
+
+		obj, ok := defs[spec.Subtrees[0].Name()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not exported", errscm.ErrUnboundVariable, spec.Subtrees[0].Name())
+		}
+
+		delete(defs, spec.Subtrees[0].Name())
+		renamed[spec.Subtrees[1].Name()] = obj
+	}
+
+	for name, obj := range renamed {
+		defs[name] = obj
+	}
+
+	return defs, nil
+}
+
+// includeExpr - (include file ...) evaluates the forms
+// read from the files in place of the expression
+func includeExpr(m *machine, ast *data.AST, ctx *data.Context) error {
+	if !withinProgram(ctx) {
+		return fmt.Errorf("%w: %s is not allowed within restricted environments", errscm.ErrBadSyntax, ast.Head())
+	}
+
+	forms, err := include(ast)
+	if err != nil {
+		return err
+	}
+
+	return sequence(m, forms, ctx)
+}
+
+// withinProgram - reports whether the context belongs to a program or
+// a library rather than to an environment made by `environment` or
+// `null-environment`, where the code must not touch files
+func withinProgram(ctx *data.Context) bool {
+	for ; ctx != nil; ctx = ctx.Outer() {
+		if ctx.TopLevel() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// include - reads the forms of the files named by the include form,
+// relative file names are resolved against the including file
+func include(ast *data.AST) ([]*data.AST, error) {
+	forms := make([]*data.AST, 0)
+	for _, file := range ast.Subtrees[1:] {
+		if file.Kind != data.Literal || file.Token.Type() != data.String {
+			return nil, fmt.Errorf("%w: %s expects file names", errscm.ErrBadSyntax, ast.Head())
+		}
+
+		read, err := readSource(relativePath(file.Identifier(), file))
+		if err != nil {
+			return nil, err
+		}
+
+		forms = append(forms, read...)
+	}
+
+	return forms, nil
+}
+
+// source - top-level forms of the file parsed
+// at its given modification time
+type source struct {
+	modTime time.Time
+	forms   []*data.AST
+}
+
+// sources - parsed source files keyed by their paths
+var sources = make(map[string]source)
+
+// readSource - reads top-level forms of the source file, files
+// are parsed again only when they are modified
+func readSource(path string) ([]*data.AST, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if src, ok := sources[path]; ok && src.modTime.Equal(info.ModTime()) {
+		return src.forms, nil
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := lexer.LexFile(bytes.Runes(bs), path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	forms := parser.Parse(ts).Subtrees
+	sources[path] = source{modTime: info.ModTime(), forms: forms}
+	return forms, nil
+}
+
+// relativePath - resolves relative path against the directory of
+// the source file the form was read from, paths used by the code
+// that is not read from a file are left as they are
+func relativePath(path string, form *data.AST) string {
+	if filepath.IsAbs(path) || form == nil || form.Token == nil || form.Token.Meta() == nil || form.Token.Meta().File() == "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(form.Token.Meta().File()), path)
+}
+
+// condExpandExpr - (cond-expand (requirement expr ...) ...) evaluates
+// the expressions of the first clause whose requirement is satisfied.
+// Restricted environments do not load libraries to check them
+func condExpandExpr(m *machine, ast *data.AST, ctx *data.Context) error {
+	body, err := condExpand(ast, withinProgram(ctx))
+	if err != nil {
+		return err
+	}
+
+	return sequence(m, body, ctx)
+}
+
+// condExpand - selects the body of the first cond-expand clause whose
+// feature requirement is satisfied, nil if there is none. Libraries
+// are loaded from the search path to check them if load is set
+func condExpand(ast *data.AST, load bool) ([]*data.AST, error) {
+	for _, clause := range ast.Subtrees[1:] {
+		if !clause.IsForm() || len(clause.Subtrees) == 0 {
+			return nil, fmt.Errorf("%w: cond-expand clause must be (requirement body ...)", errscm.ErrBadSyntax)
+		}
+
+		req := clause.Subtrees[0]
+		if req.Kind == data.VariableRef && req.Name() == "else" {
+			return clause.Subtrees[1:], nil
+		}
+
+		ok, err := requirement(req, load)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return clause.Subtrees[1:], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// requirement - reports whether the feature requirement is satisfied:
+// a feature identifier, (library name), (and ...), (or ...) or (not ...)
+func requirement(req *data.AST, load bool) (bool, error) {
+	if req.Kind == data.VariableRef {
+		for _, feature := range features {
+			if feature == req.Name() {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	if !req.IsForm() || len(req.Subtrees) == 0 {
+		return false, fmt.Errorf("%w: feature requirement must be identifier or non-empty form", errscm.ErrBadSyntax)
+	}
+
+	args := req.Subtrees[1:]
+	switch req.Head() {
+	case "library":
+		if len(args) != 1 {
+			return false, fmt.Errorf("%w: library requirement expects library name", errscm.ErrBadSyntax)
+		}
+
+		name, err := libraryName(args[0])
+		if err != nil {
+			return false, err
+		}
+
+		if !load {
+			_, defined := libraries[name]
+			_, builtin := core.Library(name)
+			return defined || builtin, nil
+		}
+
+		_, err = findLibrary(name)
+		return err == nil, nil
+	case "and", "or":
+		and := req.Head() == "and"
+		for _, arg := range args {
+			ok, err := requirement(arg, load)
+			if err != nil {
+				return false, err
+			}
+
+			if ok != and {
+				return ok, nil
+			}
+		}
+
+		return and, nil
+	case "not":
+		if len(args) != 1 {
+			return false, fmt.Errorf("%w: not requirement expects 1 requirement", errscm.ErrBadSyntax)
+		}
+
+		ok, err := requirement(args[0], load)
+		return !ok, err
+	}
+
+	return false, fmt.Errorf("%w: invalid feature requirement", errscm.ErrBadSyntax)
+}
+
+// featuresProc - `features` primitive: list of the
+// feature identifiers recognized by cond-expand
+func featuresProc(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 0, 0); err != nil {
+		return err
+	}
+
+	symbols := make([]types.Object, len(features))
+	for i, feature := range features {
+		symbols[i] = types.Symbol(feature)
+	}
+
+	m.ret(types.List(symbols...))
+	return nil
+}
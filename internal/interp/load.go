@@ -0,0 +1,46 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+func init() {
+	core.Register("scheme load", "load", control(loadProc))
+}
+
+// loadProc - `load` primitive: (load filename [env]) evaluates the forms
+// of the file within the environment, the interaction environment by
+// default. Relative file names are resolved against the file of the
+// calling expression
+func loadProc(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return err
+	}
+
+	name, ok := args[0].(types.String)
+	if !ok {
+		return fmt.Errorf("%w: expected file name, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	ctx := interactionContext()
+	if len(args) == 2 {
+		env, ok := args[1].(*Environment)
+		if !ok {
+			return fmt.Errorf("%w: expected environment, got %v", errscm.ErrUnexpectedType, args[1])
+		}
+
+		ctx = env.ctx
+	}
+
+	forms, err := readSource(relativePath(string(name), m.form))
+	if err != nil {
+		return err
+	}
+
+	return sequence(m, forms, ctx)
+}
@@ -0,0 +1,243 @@
+package interp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// frame - pending computation waiting for the value
+// of a subexpression
+type frame interface {
+	resume(m *machine, value types.Object) error
+}
+
+// cont - continuation as a stack of frames. Stacks are shared
+// with captured continuations, so neither a stack nor its
+// frames are ever mutated
+type cont struct {
+	frame frame
+	next  *cont
+}
+
+// topLevel - base of the continuations of top-level evaluations.
+// It is shared by all of them, so a continuation captured by one
+// top-level expression may be resumed by another one
+var topLevel = &cont{}
+
+// machine - evaluator state. Scheme control flow is kept within
+// the continuation instead of the Go call stack, which makes
+// continuations first-class and tail calls free
+type machine struct {
+	// ast and ctx - expression to evaluate in the next step
+	ast *data.AST
+	ctx *data.Context
+	// value - value returned to the continuation in the next
+	// step when returning is set
+	value     types.Object
+	returning bool
+	// k - current continuation
+	k *cont
+	// base - bottom of the continuations of this evaluator loop,
+	// the loop ends when a value is returned to it
+	base *cont
+	// winders - dynamic-wind extents the evaluation is within,
+	// floor - the ones the loop was started within
+	winders *winder
+	floor   *winder
+	// handlers - exception handlers installed by
+	// with-exception-handler and guard, innermost first
+	handlers *handler
+	// form - expression evaluated last, errors
+	// are reported at its position
+	form *data.AST
+}
+
+// newMachine - creates evaluator loop ending at the base
+// within the given dynamic-wind extents
+func newMachine(base *cont, winders *winder) *machine {
+	return &machine{
+		k:       base,
+		base:    base,
+		winders: winders,
+		floor:   winders,
+	}
+}
+
+// eval - makes expression the next one to evaluate
+func (m *machine) eval(ast *data.AST, ctx *data.Context) {
+	m.ast, m.ctx, m.returning = ast, ctx, false
+}
+
+// ret - returns value to the current continuation
+func (m *machine) ret(value types.Object) {
+	m.value, m.returning = value, true
+}
+
+// push - pushes frame onto the current continuation
+func (m *machine) push(f frame) {
+	m.k = &cont{frame: f, next: m.k}
+}
+
+// run - evaluator loop: steps through expressions and returns
+// their values to the pending frames until the value reaches
+// the base of the continuation
+func (m *machine) run() (types.Object, error) {
+	for {
+		var err error
+		if !m.returning {
+			m.form = m.ast
+			err = evalStep(m, m.ast, m.ctx)
+		} else if m.k == m.base {
+			return m.value, nil
+		} else {
+			f := m.k.frame
+			m.k = m.k.next
+			err = f.resume(m, m.value)
+		}
+
+		if err == nil {
+			continue
+		}
+
+		// continuation of this loop invoked from a nested one
+		esc, ok := err.(*escape)
+		if ok && esc.c.base == m.base {
+			if err = m.throw(esc.c, esc.values); err == nil {
+				continue
+			}
+		}
+
+		// errors are raised as conditions to the handlers,
+		// exiting the program can not be caught
+		var exit *errscm.ExitError
+		if !ok && m.handlers != nil && !errors.As(err, &exit) {
+			if err = m.raise(condition(err), false); err == nil {
+				continue
+			}
+		}
+
+		return nil, m.abort(err)
+	}
+}
+
+// located - adds position of the last evaluated
+// expression to the error, unless it has one
+func (m *machine) located(err error) error {
+	var re *errscm.RuntimeError
+	if m.form == nil || m.form.Token == nil || m.form.Token.Meta() == nil || errors.As(err, &re) {
+		return err
+	}
+
+	meta := m.form.Token.Meta()
+	return errscm.ReportRuntimeError(meta.File(), meta.Line(), meta.Pos(), err)
+}
+
+// abort - leaves dynamic-wind extents entered within
+// the loop on error, running their after thunks. The
+// thunks are skipped on emergency exit
+func (m *machine) abort(err error) error {
+	var exit *errscm.ExitError
+	if errors.As(err, &exit) && exit.Emergency {
+		m.winders = m.floor
+		return err
+	}
+
+	for w := m.winders; w != nil && w != m.floor; w = w.next {
+		if _, afterErr := callProc(w.after, nil, w.next); afterErr != nil {
+			return afterErr
+		}
+	}
+
+	m.winders = m.floor
+	return err
+}
+
+// callProc - applies procedure to the arguments within a new
+// evaluator loop, used for calls made from Go code
+func callProc(proc types.Object, args []types.Object, winders *winder) (types.Object, error) {
+	m := newMachine(&cont{}, winders)
+	if err := m.apply(proc, args); err != nil {
+		return nil, m.abort(err)
+	}
+
+	return m.run()
+}
+
+// apply - applies procedure to the arguments. Scheme functions
+// are not called directly: their body is evaluated next within
+// the scope of bound arguments
+func (m *machine) apply(proc types.Object, args []types.Object) error {
+	switch fn := proc.(type) {
+	case *Func:
+		return applyFunc(m, fn, args)
+	case *CaseLambda:
+		clause, err := fn.dispatch(args)
+		if err != nil {
+			return err
+		}
+
+		return applyFunc(m, clause, args)
+	case control:
+		return fn(m, args)
+	case *Continuation:
+		if fn.base != m.base {
+			return &escape{c: fn, values: args}
+		}
+
+		return m.throw(fn, args)
+	case types.Callable:
+		result, err := fn.Call(args...)
+		if err != nil {
+			return err
+		}
+
+		return m.result(result)
+	}
+
+	return fmt.Errorf(`"%v" is not a function`, proc)
+}
+
+// result - returns builtin's result to the continuation,
+// carrying out its request to apply a procedure, if it is one
+func (m *machine) result(obj types.Object) error {
+	req, ok := obj.(*types.Apply)
+	if !ok {
+		m.ret(obj)
+		return nil
+	}
+
+	if req.Then != nil {
+		m.push(thenFrame{then: req.Then})
+	}
+
+	return m.apply(req.Proc, req.Args)
+}
+
+// thenFrame - passes the result of the requested call
+// back to the builtin
+type thenFrame struct {
+	then func(types.Object) (types.Object, error)
+}
+
+func (f thenFrame) resume(m *machine, value types.Object) error {
+	result, err := f.then(value)
+	if err != nil {
+		return err
+	}
+
+	return m.result(result)
+}
+
+// single - asserts that the value is not multiple values
+// in a context expecting exactly one, e.g. an argument or a test
+func single(value types.Object) error {
+	if vs, ok := value.(types.Values); ok {
+		return fmt.Errorf("%w: expected 1 value, got %d", errscm.ErrWrongNumberOfValues, len(vs))
+	}
+
+	return nil
+}
@@ -0,0 +1,599 @@
+package interp
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// Macro - syntax transformer, the forms it is used in are rewritten
+// before their evaluation: either by the first matching rule of
+// syntax-rules or by the procedure of er-macro-transformer.
+// Identifiers introduced by the transformer are renamed, so they
+// neither capture nor get captured by the bindings of the use site
+type Macro struct {
+	Name     string
+	literals map[string]bool
+	ellipsis string
+	rules    []syntaxRule
+	// proc - procedure of the explicit renaming transformer
+	proc types.Object
+	// ctx - context of the macro definition, where
+	// the renamed identifiers are looked up
+	ctx *data.Context
+}
+
+// syntaxRule - pattern and template of a syntax-rules clause
+type syntaxRule struct {
+	pattern  *data.AST
+	template *data.AST
+}
+
+// Value - types.Object interface implementation
+func (mac *Macro) Value() any {
+	return "syntax"
+}
+
+func (mac *Macro) String() string {
+	return fmt.Sprintf("#<syntax %s>", mac.Name)
+}
+
+// renames - counter of the renamed identifiers, the suffixes
+// contain '#', so the new names never clash with the source ones
+var renames int
+
+// lookup - finds definition of the identifier, renamed identifiers
+// that are not bound are looked up as their origin within the
+// context of the macro definition
+func lookup(id *data.AST, ctx *data.Context) (types.Object, bool) {
+	for {
+		if def, ok := ctx.FindDef(id.Identifier()); ok {
+			return def, true
+		}
+
+		if id.Origin == nil {
+			return nil, false
+		}
+
+		id, ctx = id.Origin, id.Ctx
+	}
+}
+
+// owner - finds the context the identifier is bound within
+// the same way as lookup, along with the name it is bound by
+func owner(id *data.AST, ctx *data.Context) (*data.Context, string, bool) {
+	for {
+		if found, ok := ctx.Binding(id.Identifier()); ok {
+			return found, id.Identifier(), true
+		}
+
+		if id.Origin == nil {
+			return nil, "", false
+		}
+
+		id, ctx = id.Origin, id.Ctx
+	}
+}
+
+// sameBinding - reports whether the identifiers looked up within their
+// contexts refer to the same binding, unbound ones are the same if
+// they have the same name
+func sameBinding(a *data.AST, actx *data.Context, b *data.AST, bctx *data.Context) bool {
+	aOwner, aName, aBound := owner(a, actx)
+	bOwner, bName, bBound := owner(b, bctx)
+	if !aBound && !bBound {
+		return a.Name() == b.Name()
+	}
+
+	return aBound && bBound && aOwner == bOwner && aName == bName
+}
+
+// assign - rebinds the variable found the same way as by lookup
+func assign(id *data.AST, ctx *data.Context, value types.Object) bool {
+	for {
+		if ctx.Assign(id.Identifier(), value) {
+			return true
+		}
+
+		if id.Origin == nil {
+			return false
+		}
+
+		id, ctx = id.Origin, id.Ctx
+	}
+}
+
+// macro - reports whether the identifier is bound to a macro
+func macro(id *data.AST, ctx *data.Context) (*Macro, bool) {
+	if id.Kind != data.VariableRef {
+		return nil, false
+	}
+
+	def, _ := lookup(id, ctx)
+	mac, ok := def.(*Macro)
+	return mac, ok
+}
+
+// defineSyntax - (define-syntax name transformer) binds
+// the macro within the current context
+func defineSyntax(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) != 3 {
+		return fmt.Errorf("%w: expected 2 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
+	}
+
+	id := ast.Subtrees[1]
+	if id.Kind != data.VariableRef {
+		return fmt.Errorf("%w: define-syntax expects identifier", errscm.ErrBadSyntax)
+	}
+
+	m.push(funcFrame(func(m *machine, value types.Object) error {
+		mac, err := keyword(value, id, ctx)
+		if err != nil {
+			return err
+		}
+
+		ctx.Define(id.Identifier(), mac)
+		m.ret(nil)
+		return nil
+	}))
+
+	m.eval(ast.Subtrees[2], ctx)
+	return nil
+}
+
+// letSyntax - (let-syntax ((name transformer) ...) body...) evaluates
+// the body with the macros bound within a new scope. Transformers of
+// letrec-syntax are evaluated within the new scope, so they may refer
+// to each other
+func letSyntax(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 3 {
+		return fmt.Errorf("%w: missing %s body", errscm.ErrTooLittleArguments, ast.Head())
+	}
+
+	list := ast.Subtrees[1]
+	if !list.IsForm() {
+		return fmt.Errorf("%w: %s expects binding list", errscm.ErrBadSyntax, ast.Head())
+	}
+
+	scope := ctx.Spawn()
+	env := ctx
+	if ast.Kind == data.LetrecSyntaxExpr {
+		env = scope
+	}
+
+	ids := make([]*data.AST, len(list.Subtrees))
+	specs := make([]*data.AST, len(list.Subtrees))
+	for i, b := range list.Subtrees {
+		if !b.IsForm() || len(b.Subtrees) != 2 || b.Subtrees[0].Kind != data.VariableRef {
+			return fmt.Errorf("%w: %s binding must be (name transformer)", errscm.ErrBadSyntax, ast.Head())
+		}
+
+		ids[i], specs[i] = b.Subtrees[0], b.Subtrees[1]
+	}
+
+	return evalAll(m, specs, env, func(m *machine, objs []types.Object) error {
+		for i, obj := range objs {
+			mac, err := keyword(obj, ids[i], env)
+			if err != nil {
+				return err
+			}
+
+			scope.Define(ids[i].Identifier(), mac)
+		}
+
+		return sequence(m, ast.Subtrees[2:], scope)
+	})
+}
+
+// keyword - names the transformer after the keyword it is bound to,
+// procedural transformers get the context of the definition
+func keyword(obj types.Object, id *data.AST, ctx *data.Context) (*Macro, error) {
+	mac, ok := obj.(*Macro)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected transformer for %s, got %v", errscm.ErrBadSyntax, id.Name(), obj)
+	}
+
+	named := *mac
+	named.Name = id.Name()
+	if named.ctx == nil {
+		named.ctx = ctx
+	}
+
+	return &named, nil
+}
+
+// syntaxRules - (syntax-rules [ellipsis] (literal ...) (pattern template) ...)
+// creates macro closed over the current context
+func syntaxRules(spec *data.AST, ctx *data.Context) (types.Object, error) {
+	mac := &Macro{
+		literals: make(map[string]bool),
+		ellipsis: "...",
+		ctx:      ctx,
+	}
+
+	elems := spec.Subtrees[1:]
+	if len(elems) > 0 && elems[0].Kind == data.VariableRef {
+		mac.ellipsis = elems[0].Name()
+		elems = elems[1:]
+	}
+
+	if len(elems) == 0 || !elems[0].IsForm() {
+		return nil, fmt.Errorf("%w: syntax-rules expects literal list", errscm.ErrBadSyntax)
+	}
+
+	for _, lit := range elems[0].Subtrees {
+		if lit.Kind != data.VariableRef {
+			return nil, fmt.Errorf("%w: syntax-rules literal must be identifier", errscm.ErrBadSyntax)
+		}
+
+		mac.literals[lit.Name()] = true
+	}
+
+	for _, r := range elems[1:] {
+		if !r.IsForm() || len(r.Subtrees) != 2 || !r.Subtrees[0].IsForm() || len(r.Subtrees[0].Subtrees) == 0 {
+			return nil, fmt.Errorf("%w: syntax rule must be ((keyword pattern ...) template)", errscm.ErrBadSyntax)
+		}
+
+		if _, _, ok := r.Subtrees[0].Split(); !ok {
+			return nil, fmt.Errorf("%w: misplaced dot in syntax rule pattern", errscm.ErrBadSyntax)
+		}
+
+		mac.rules = append(mac.rules, syntaxRule{pattern: r.Subtrees[0], template: r.Subtrees[1]})
+	}
+
+	return mac, nil
+}
+
+// Expand - rewrites the form used within the context by the first rule
+// which pattern matches it, the keyword position of the patterns is ignored
+func (mac *Macro) Expand(form *data.AST, ctx *data.Context) (*data.AST, error) {
+	elems, rest, ok := form.Split()
+	if !ok {
+		return nil, fmt.Errorf("%w: misplaced dot", errscm.ErrBadSyntax)
+	}
+
+	for _, r := range mac.rules {
+		pElems, pRest, _ := r.pattern.Split()
+		bs := make(bindings)
+		if !mac.matchList(pElems[1:], pRest, elems[1:], rest, form, ctx, bs) {
+			continue
+		}
+
+		return newExpansion(mac, form).expand(r.template, bs, false)
+	}
+
+	return nil, fmt.Errorf("%w: no syntax rule of %s matches %s", errscm.ErrBadSyntax, mac.Name, show(form))
+}
+
+// bound - value of the pattern variable: the matched form, or
+// the sequence of values for the variables followed by ellipsis
+type bound struct {
+	form *data.AST
+	seq  []bound
+}
+
+// bindings - values of the pattern variables by their names
+type bindings map[string]bound
+
+// isEllipsis - reports whether the node is the ellipsis of the macro
+func (mac *Macro) isEllipsis(ast *data.AST) bool {
+	return ast.Kind == data.VariableRef && ast.Name() == mac.ellipsis
+}
+
+// match - matches the form used within the context against the
+// pattern, binding the pattern variables. Literals match the
+// identifiers with the same binding, underscore matches
+// anything and binds nothing
+func (mac *Macro) match(pattern, form *data.AST, ctx *data.Context, bs bindings) bool {
+	switch {
+	case pattern.Kind == data.VariableRef:
+		switch name := pattern.Name(); {
+		case name == "_":
+		case mac.literals[name]:
+			return form.Kind == data.VariableRef && sameBinding(pattern, mac.ctx, form, ctx)
+		default:
+			bs[pattern.Identifier()] = bound{form: form}
+		}
+
+		return true
+	case pattern.IsForm():
+		if !form.IsForm() {
+			return false
+		}
+
+		pElems, pRest, _ := pattern.Split()
+		elems, rest, ok := form.Split()
+		return ok && mac.matchList(pElems, pRest, elems, rest, form, ctx, bs)
+	case pattern.Kind == data.Vector:
+		return form.Kind == data.Vector && mac.matchList(pattern.Subtrees, nil, form.Subtrees, nil, form, ctx, bs)
+	case pattern.Kind == data.Literal:
+		return form.Kind == data.Literal &&
+			form.Token.Type() == pattern.Token.Type() &&
+			form.Identifier() == pattern.Identifier()
+	}
+
+	return false
+}
+
+// matchList - matches the elements of the list, possibly improper,
+// against the patterns, one of which may be followed by ellipsis
+// and match any number of the elements
+func (mac *Macro) matchList(patterns []*data.AST, pRest *data.AST, elems []*data.AST, rest, form *data.AST, ctx *data.Context, bs bindings) bool {
+	at := -1
+	for i := 0; i+1 < len(patterns); i++ {
+		if mac.isEllipsis(patterns[i+1]) {
+			at = i
+			break
+		}
+	}
+
+	if at < 0 {
+		if len(elems) < len(patterns) || pRest == nil && (len(elems) != len(patterns) || rest != nil) {
+			return false
+		}
+
+		for i, p := range patterns {
+			if !mac.match(p, elems[i], ctx, bs) {
+				return false
+			}
+		}
+
+		return pRest == nil || mac.match(pRest, tail(form, elems[len(patterns):], rest), ctx, bs)
+	}
+
+	after := patterns[at+2:]
+	n := len(elems) - at - len(after)
+	if n < 0 || pRest == nil && rest != nil {
+		return false
+	}
+
+	for i, p := range patterns[:at] {
+		if !mac.match(p, elems[i], ctx, bs) {
+			return false
+		}
+	}
+
+	names := mac.vars(patterns[at], nil)
+	seqs := make(map[string][]bound, len(names))
+	for _, name := range names {
+		seqs[name] = make([]bound, 0, n)
+	}
+
+	for _, elem := range elems[at : at+n] {
+		sub := make(bindings)
+		if !mac.match(patterns[at], elem, ctx, sub) {
+			return false
+		}
+
+		for _, name := range names {
+			seqs[name] = append(seqs[name], sub[name])
+		}
+	}
+
+	for name, seq := range seqs {
+		bs[name] = bound{seq: seq}
+	}
+
+	for i, p := range after {
+		if !mac.match(p, elems[at+n+i], ctx, bs) {
+			return false
+		}
+	}
+
+	return pRest == nil || mac.match(pRest, tail(form, nil, rest), ctx, bs)
+}
+
+// vars - collects names of the pattern variables
+func (mac *Macro) vars(pattern *data.AST, names []string) []string {
+	if pattern.Kind == data.VariableRef {
+		name := pattern.Name()
+		if name == "_" || name == mac.ellipsis || mac.literals[name] {
+			return names
+		}
+
+		return append(names, pattern.Identifier())
+	}
+
+	for _, st := range pattern.Subtrees {
+		names = mac.vars(st, names)
+	}
+
+	return names
+}
+
+// tail - the rest of the matched list as a form of its own
+func tail(form *data.AST, elems []*data.AST, rest *data.AST) *data.AST {
+	if len(elems) == 0 && rest != nil {
+		return rest
+	}
+
+	subtrees := make([]*data.AST, len(elems), len(elems)+2)
+	copy(subtrees, elems)
+	if rest != nil {
+		subtrees = append(subtrees, form.Subtrees[len(form.Subtrees)-2], rest)
+	}
+
+	node := &data.AST{Token: form.Token, Subtrees: subtrees}
+	node.Classify()
+	return node
+}
+
+// expansion - state of a single macro expansion, all occurrences
+// of an introduced identifier are renamed the same way. Forms built
+// by the expansion are placed at the position of the macro use
+type expansion struct {
+	mac     *Macro
+	form    *data.AST
+	renamed map[string]*data.AST
+	// sources - forms of the macro use passed to the procedural
+	// transformer as pairs and vectors, the expansion keeps
+	// positions of the ones returned untouched
+	sources map[types.Object]*data.AST
+}
+
+// newExpansion - starts expansion of the macro use
+func newExpansion(mac *Macro, form *data.AST) *expansion {
+	return &expansion{
+		mac:     mac,
+		form:    form,
+		renamed: make(map[string]*data.AST),
+		sources: make(map[types.Object]*data.AST),
+	}
+}
+
+// expand - instantiates the template with the values of the pattern
+// variables. Escaped templates, (... template), treat the ellipsis
+// as an ordinary identifier
+func (e *expansion) expand(tmpl *data.AST, bs bindings, escaped bool) (*data.AST, error) {
+	switch {
+	case tmpl.Kind == data.VariableRef:
+		if b, ok := bs[tmpl.Identifier()]; ok {
+			if b.form == nil {
+				return nil, fmt.Errorf("%w: pattern variable %s is used without ellipsis", errscm.ErrBadSyntax, tmpl.Name())
+			}
+
+			return b.form, nil
+		}
+
+		return e.rename(tmpl), nil
+	case tmpl.IsForm():
+		elems, rest, _ := tmpl.Split()
+		if !escaped && len(elems) == 2 && rest == nil && e.mac.isEllipsis(elems[0]) {
+			return e.expand(elems[1], bs, true)
+		}
+
+		subtrees, err := e.expandElems(elems, bs, escaped)
+		if err != nil {
+			return nil, err
+		}
+
+		if rest != nil {
+			last, err := e.expand(rest, bs, escaped)
+			if err != nil {
+				return nil, err
+			}
+
+			// dotted tails expanded into lists are spliced
+			if last.IsForm() {
+				subtrees = append(subtrees, last.Subtrees...)
+			} else {
+				subtrees = append(subtrees, tmpl.Subtrees[len(tmpl.Subtrees)-2], last)
+			}
+		}
+
+		node := &data.AST{Token: e.form.Token, Subtrees: subtrees}
+		node.Classify()
+		return node, nil
+	case tmpl.Kind == data.Vector:
+		subtrees, err := e.expandElems(tmpl.Subtrees, bs, escaped)
+		if err != nil {
+			return nil, err
+		}
+
+		return &data.AST{Token: tmpl.Token, Kind: data.Vector, Subtrees: subtrees}, nil
+	}
+
+	return tmpl, nil
+}
+
+// expandElems - expands elements of the list template, the ones
+// followed by ellipses are repeated for every matched value
+func (e *expansion) expandElems(elems []*data.AST, bs bindings, escaped bool) ([]*data.AST, error) {
+	out := make([]*data.AST, 0, len(elems))
+	for i := 0; i < len(elems); i++ {
+		elem, depth := elems[i], 0
+		for !escaped && i+1 < len(elems) && e.mac.isEllipsis(elems[i+1]) {
+			depth++
+			i++
+		}
+
+		items, err := e.repeat(elem, bs, depth, escaped)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, items...)
+	}
+
+	return out, nil
+}
+
+// repeat - expands the template once for every value of the
+// sequence variables it contains, depth is the number of
+// ellipses following the template
+func (e *expansion) repeat(tmpl *data.AST, bs bindings, depth int, escaped bool) ([]*data.AST, error) {
+	if depth == 0 {
+		node, err := e.expand(tmpl, bs, escaped)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*data.AST{node}, nil
+	}
+
+	n := -1
+	names := make([]string, 0)
+	for _, name := range e.mac.vars(tmpl, nil) {
+		b, ok := bs[name]
+		if !ok || b.form != nil {
+			continue
+		}
+
+		if n >= 0 && n != len(b.seq) {
+			return nil, fmt.Errorf("%w: pattern variables under ellipsis have different lengths", errscm.ErrBadSyntax)
+		}
+
+		n = len(b.seq)
+		names = append(names, name)
+	}
+
+	if n < 0 {
+		return nil, fmt.Errorf("%w: no pattern variables before ellipsis in template", errscm.ErrBadSyntax)
+	}
+
+	out := make([]*data.AST, 0, n)
+	for i := 0; i < n; i++ {
+		sub := make(bindings, len(bs))
+		for name, b := range bs {
+			sub[name] = b
+		}
+
+		for _, name := range names {
+			sub[name] = bs[name].seq[i]
+		}
+
+		items, err := e.repeat(tmpl, sub, depth-1, escaped)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, items...)
+	}
+
+	return out, nil
+}
+
+// rename - renames the identifier introduced by the template
+func (e *expansion) rename(id *data.AST) *data.AST {
+	if r, ok := e.renamed[id.Identifier()]; ok {
+		return r
+	}
+
+	renames++
+	r := id.Rename(id.Identifier()+"#"+strconv.Itoa(renames), e.mac.ctx)
+	e.renamed[id.Identifier()] = r
+	return r
+}
+
+// show - source-like representation of the form for error messages
+func show(form *data.AST) string {
+	obj, err := datum(form)
+	if err != nil {
+		return form.Head()
+	}
+
+	return fmt.Sprint(obj)
+}
@@ -0,0 +1,95 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// parameterize - (parameterize ((param value) ...) body...) evaluates
+// the body with the parameters bound to the converted values. The
+// bindings are swapped in and out by a dynamic-wind extent, so the
+// previous values are restored whenever the control leaves the body:
+// on return, on error and by a continuation
+func parameterize(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 3 {
+		return fmt.Errorf("%w: missing parameterize body", errscm.ErrTooLittleArguments)
+	}
+
+	list := ast.Subtrees[1]
+	if !list.IsForm() {
+		return fmt.Errorf("%w: parameterize expects binding list", errscm.ErrBadSyntax)
+	}
+
+	exprs := make([]*data.AST, 0, 2*len(list.Subtrees))
+	for _, b := range list.Subtrees {
+		if !b.IsForm() || len(b.Subtrees) != 2 {
+			return fmt.Errorf("%w: parameterize binding must be (param value)", errscm.ErrBadSyntax)
+		}
+
+		exprs = append(exprs, b.Subtrees...)
+	}
+
+	body := NewFunc(ctx, nil, "", ast.Subtrees[2:])
+	return evalAll(m, exprs, ctx, func(m *machine, objs []types.Object) error {
+		params := make([]*types.Parameter, len(objs)/2)
+		for i := range params {
+			p, ok := objs[2*i].(*types.Parameter)
+			if !ok {
+				return fmt.Errorf("%w: expected parameter object, got %v", errscm.ErrUnexpectedType, objs[2*i])
+			}
+
+			params[i] = p
+		}
+
+		return convert(m, params, objs, 0, func(m *machine, values []types.Object) error {
+			swap := control(func(m *machine, _ []types.Object) error {
+				for i, p := range params {
+					value := p.Get()
+					p.Set(values[i])
+					values[i] = value
+				}
+
+				m.ret(nil)
+				return nil
+			})
+
+			m.push(windFrame{before: swap, thunk: body, after: swap})
+			return m.apply(swap, nil)
+		})
+	})
+}
+
+// convert - applies converters of the parameters to the values
+// one by one, the values follow their parameters in objs
+func convert(m *machine, params []*types.Parameter, objs []types.Object, i int, then func(*machine, []types.Object) error) error {
+	if i == len(params) {
+		values := make([]types.Object, len(params))
+		for j := range params {
+			values[j] = objs[2*j+1]
+		}
+
+		return then(m, values)
+	}
+
+	if params[i].Converter == nil {
+		return convert(m, params, objs, i+1, then)
+	}
+
+	m.push(funcFrame(func(m *machine, value types.Object) error {
+		if err := single(value); err != nil {
+			return err
+		}
+
+		// the frame may be resumed again, so objs are copied
+		converted := make([]types.Object, len(objs))
+		copy(converted, objs)
+		converted[2*i+1] = value
+
+		return convert(m, params, converted, i+1, then)
+	}))
+
+	return m.apply(params[i].Converter, []types.Object{objs[2*i+1]})
+}
@@ -0,0 +1,173 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+func init() {
+	core.Register("scheme lazy", "force", control(forceProc))
+	core.Register("scheme lazy", "make-promise", control(makePromise))
+	core.Register("scheme lazy", "promise?", control(isPromise))
+}
+
+// Promise - delayed evaluation, which result is memoized.
+// Promises chained by delay-force share the box, so forcing
+// one of them forces them all
+type Promise struct {
+	box *promiseBox
+}
+
+// promiseBox - state of the promise
+type promiseBox struct {
+	done  bool
+	value types.Object
+	// expr and ctx - delayed expression,
+	// thunk - delayed call of a procedure without arguments
+	expr  *data.AST
+	ctx   *data.Context
+	thunk types.Object
+	// lazy - delayed expression evaluates to a promise,
+	// which is forced in its place
+	lazy bool
+}
+
+// ready - creates forced promise of the value
+func ready(value types.Object) *Promise {
+	return &Promise{box: &promiseBox{done: true, value: value}}
+}
+
+// Value - types.Object interface implementation
+func (p *Promise) Value() any {
+	return "promise"
+}
+
+func (p *Promise) String() string {
+	return "#<promise>"
+}
+
+// delay - (delay expr) and (delay-force expr)
+// create promises of the expression
+func delay(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	if len(ast.Subtrees) != 2 {
+		return nil, fmt.Errorf("%w: expected 1 arg, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
+	}
+
+	return &Promise{box: &promiseBox{
+		expr: ast.Subtrees[1],
+		ctx:  ctx,
+		lazy: ast.Kind == data.DelayForceExpr,
+	}}, nil
+}
+
+// force - returns value of the promise, evaluating it when
+// it is not done yet. Promises returned by delay-force
+// expressions are forced in the same loop, so chains
+// of them are forced in constant space
+func force(m *machine, p *Promise) error {
+	b := p.box
+	if b.done {
+		m.ret(b.value)
+		return nil
+	}
+
+	m.push(forceFrame{promise: p})
+	if b.thunk != nil {
+		return m.apply(b.thunk, nil)
+	}
+
+	m.eval(b.expr, b.ctx)
+	return nil
+}
+
+// forceFrame - makes the promise take over the state of the one
+// the delayed expression evaluates to, unless a reentrant force
+// has done the promise already, then forces the promise again
+type forceFrame struct {
+	promise *Promise
+}
+
+func (f forceFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	p := f.promise
+	if !p.box.done {
+		next, ok := value.(*Promise)
+		if !p.box.lazy || !ok {
+			next = ready(value)
+		}
+
+		*p.box = *next.box
+		next.box = p.box
+	}
+
+	return force(m, p)
+}
+
+// forceThen - forces the object, if it is a promise,
+// and continues with its value
+func forceThen(m *machine, obj types.Object, then func(*machine, types.Object) error) error {
+	m.push(funcFrame(then))
+	return forceObject(m, obj)
+}
+
+// forceObject - forces promises, other objects are returned as is
+func forceObject(m *machine, obj types.Object) error {
+	p, ok := obj.(*Promise)
+	if !ok {
+		m.ret(obj)
+		return nil
+	}
+
+	return force(m, p)
+}
+
+// funcFrame - continues with the func
+type funcFrame func(*machine, types.Object) error
+
+func (f funcFrame) resume(m *machine, value types.Object) error {
+	return f(m, value)
+}
+
+// forceProc - `force` primitive
+func forceProc(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return forceObject(m, args[0])
+}
+
+// makePromise - `make-promise` primitive: returns forced promise
+// of the object, promises are returned as is
+func makePromise(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	if p, ok := args[0].(*Promise); ok {
+		m.ret(p)
+		return nil
+	}
+
+	m.ret(ready(args[0]))
+	return nil
+}
+
+// isPromise - `promise?` primitive
+func isPromise(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	_, ok := args[0].(*Promise)
+	m.ret(types.Boolean(ok))
+	return nil
+}
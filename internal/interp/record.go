@@ -0,0 +1,114 @@
+package interp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core/records"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// defineRecordType - (define-record-type <name> (constructor field ...)
+// predicate (field accessor [modifier]) ...) defines the record type
+// along with its procedures. The constructor may be #f for none or
+// a bare identifier for the one accepting all the fields
+func defineRecordType(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	if len(ast.Subtrees) < 4 {
+		return nil, fmt.Errorf("%w: define-record-type expects type name, constructor and predicate", errscm.ErrTooLittleArguments)
+	}
+
+	name, ctor, pred := ast.Subtrees[1], ast.Subtrees[2], ast.Subtrees[3]
+	if name.Kind != data.VariableRef {
+		return nil, fmt.Errorf("%w: record type name must be identifier", errscm.ErrBadSyntax)
+	}
+
+	rt := &types.RecordType{Name: strings.TrimSuffix(strings.TrimPrefix(name.Name(), "<"), ">")}
+	fields := make(map[string]int)
+	specs := ast.Subtrees[4:]
+	for i, spec := range specs {
+		if !spec.IsForm() || len(spec.Subtrees) == 0 || len(spec.Subtrees) > 3 || !identifiers(spec.Subtrees) {
+			return nil, fmt.Errorf("%w: record field must be (field [accessor [modifier]])", errscm.ErrBadSyntax)
+		}
+
+		field := spec.Subtrees[0].Name()
+		if _, ok := fields[field]; ok {
+			return nil, fmt.Errorf("%w: duplicate record field %s", errscm.ErrBadSyntax, field)
+		}
+
+		fields[field] = i
+		rt.Fields = append(rt.Fields, field)
+	}
+
+	defs := make(map[*data.AST]types.Object)
+	if err := recordConstructor(rt, ctor, fields, defs); err != nil {
+		return nil, err
+	}
+
+	if pred.Kind != data.VariableRef {
+		return nil, fmt.Errorf("%w: record predicate name must be identifier", errscm.ErrBadSyntax)
+	}
+
+	defs[pred] = records.Predicate(rt)
+	for i, spec := range specs {
+		if len(spec.Subtrees) > 1 {
+			defs[spec.Subtrees[1]] = records.Accessor(rt, i, spec.Subtrees[1].Name())
+		}
+
+		if len(spec.Subtrees) > 2 {
+			defs[spec.Subtrees[2]] = records.Modifier(rt, i, spec.Subtrees[2].Name())
+		}
+	}
+
+	// procedures take precedence over the type sharing their name
+	ctx.Define(name.Identifier(), rt)
+	for id, def := range defs {
+		ctx.Define(id.Identifier(), def)
+	}
+
+	return nil, nil
+}
+
+// recordConstructor - adds the constructor described by the spec
+// to the definitions
+func recordConstructor(rt *types.RecordType, spec *data.AST, fields map[string]int, defs map[*data.AST]types.Object) error {
+	switch {
+	case spec.Kind == data.Literal && spec.Token.Type() == data.Boolean && spec.Identifier() == "f":
+		return nil
+	case spec.Kind == data.VariableRef:
+		all := make([]int, len(rt.Fields))
+		for i := range all {
+			all[i] = i
+		}
+
+		defs[spec] = records.Constructor(rt, all)
+		return nil
+	case !spec.IsForm() || len(spec.Subtrees) == 0 || !identifiers(spec.Subtrees):
+		return fmt.Errorf("%w: record constructor must be (name field ...)", errscm.ErrBadSyntax)
+	}
+
+	args := make([]int, 0, len(spec.Subtrees)-1)
+	for _, arg := range spec.Subtrees[1:] {
+		i, ok := fields[arg.Name()]
+		if !ok {
+			return fmt.Errorf("%w: %s is not a field of %s", errscm.ErrBadSyntax, arg.Name(), rt.Name)
+		}
+
+		args = append(args, i)
+	}
+
+	defs[spec.Subtrees[0]] = records.Constructor(rt, args)
+	return nil
+}
+
+// identifiers - reports whether all the nodes are identifiers
+func identifiers(asts []*data.AST) bool {
+	for _, ast := range asts {
+		if ast.Kind != data.VariableRef {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+package interp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// searchPath - directories searched for the files
+// of the libraries, in the order of priority
+var searchPath []string
+
+// libraryExtensions - extensions of the library
+// files, in the order of priority
+var libraryExtensions = []string{".sld", ".scm"}
+
+// loading - names of the libraries being loaded, each
+// one imported by the previous one
+var loading []string
+
+// SetSearchPath - sets directories searched for the files of the
+// libraries that are not defined yet. Library (mylib utils) is
+// looked up as mylib/utils.sld and mylib/utils.scm in each of them
+func SetSearchPath(dirs ...string) {
+	searchPath = dirs
+}
+
+// libraryFile - finds the file of the library in the search path
+func libraryFile(name string) (string, bool) {
+	rel := filepath.Join(strings.Split(name, " ")...)
+	for _, dir := range searchPath {
+		for _, ext := range libraryExtensions {
+			path := filepath.Join(dir, rel+ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// loadLibrary - evaluates the file of the library found in the search
+// path, the file is expected to define the library. Libraries imported
+// while loading the file are loaded first, importing a library that
+// is being loaded is reported as a cycle
+func loadLibrary(name string) (*Library, error) {
+	path, ok := libraryFile(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: (%s)", errscm.ErrUnknownLibrary, name)
+	}
+
+	for i, l := range loading {
+		if l == name {
+			chain := make([]string, 0, len(loading)-i+1)
+			for _, l := range loading[i:] {
+				chain = append(chain, "("+l+")")
+			}
+
+			chain = append(chain, "("+name+")")
+
+			return nil, fmt.Errorf("%w: %s", errscm.ErrImportCycle, strings.Join(chain, " -> "))
+		}
+	}
+
+	loading = append(loading, name)
+	defer func() {
+		loading = loading[:len(loading)-1]
+	}()
+
+	forms, err := readSource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := data.NewContext(core.DefaultDefinitions())
+	for _, form := range forms {
+		if _, err := Eval(form, ctx); err != nil {
+			if errors.Is(err, errscm.ErrImportCycle) {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("loading (%s) from %s: %w", name, path, err)
+		}
+	}
+
+	lib, ok := libraries[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s does not define (%s)", errscm.ErrUnknownLibrary, path, name)
+	}
+
+	return lib, nil
+}
@@ -0,0 +1,184 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// and - evaluates expressions from left to right until one of them
+// is false, returns the last evaluated value or #t if there are none.
+// The last expression is in tail position
+func and(m *machine, ast *data.AST, ctx *data.Context) error {
+	return junction(m, ast.Subtrees[1:], ctx, false)
+}
+
+// or - evaluates expressions from left to right until one of them
+// is true, returns the last evaluated value or #f if there are none.
+// The last expression is in tail position
+func or(m *machine, ast *data.AST, ctx *data.Context) error {
+	return junction(m, ast.Subtrees[1:], ctx, true)
+}
+
+// junction - evaluates the first of the expressions of `and`
+// or `or`, the latter stops on true values
+func junction(m *machine, exprs []*data.AST, ctx *data.Context, stopOn bool) error {
+	switch len(exprs) {
+	case 0:
+		m.ret(types.Boolean(!stopOn))
+	case 1:
+		m.eval(exprs[0], ctx)
+	default:
+		m.push(junctionFrame{exprs: exprs[1:], ctx: ctx, stopOn: stopOn})
+		m.eval(exprs[0], ctx)
+	}
+
+	return nil
+}
+
+// junctionFrame - returns the value when its truth is the one
+// to stop on, goes on with the rest expressions otherwise
+type junctionFrame struct {
+	exprs  []*data.AST
+	ctx    *data.Context
+	stopOn bool
+}
+
+func (f junctionFrame) resume(m *machine, value types.Object) error {
+	if err := single(value); err != nil {
+		return err
+	}
+
+	if types.IsTrue(value) == f.stopOn {
+		m.ret(value)
+		return nil
+	}
+
+	return junction(m, f.exprs, f.ctx, f.stopOn)
+}
+
+// step - do loop variable with its initialiser and step
+type step struct {
+	binding
+	step *data.AST
+}
+
+// loop - parsed do loop
+type loop struct {
+	steps []step
+	// test and result expressions
+	clause   *data.AST
+	commands []*data.AST
+	ctx      *data.Context
+}
+
+// do - iteration construct:
+// (do ((var init step)...) (test expr...) command...).
+// The result expressions are in tail position.
+// Every iteration binds variables within a fresh scope, so
+// closures created by commands capture the current values
+func do(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 3 {
+		return fmt.Errorf("%w: do expects variables and termination clause", errscm.ErrBadSyntax)
+	}
+
+	steps, err := doSteps(ast.Subtrees[1])
+	if err != nil {
+		return err
+	}
+
+	clause := ast.Subtrees[2]
+	if !clause.IsForm() || len(clause.Subtrees) == 0 {
+		return fmt.Errorf("%w: do expects (test expr...) clause", errscm.ErrBadSyntax)
+	}
+
+	l := &loop{
+		steps:    steps,
+		clause:   clause,
+		commands: ast.Subtrees[3:],
+		ctx:      ctx,
+	}
+
+	inits := make([]*data.AST, len(steps))
+	for i, s := range steps {
+		inits[i] = s.init
+	}
+
+	return evalAll(m, inits, ctx, l.iterate)
+}
+
+// iterate - binds variables to the values within a new scope
+// and evaluates the test there
+func (l *loop) iterate(m *machine, values []types.Object) error {
+	scope := l.ctx.Spawn()
+	for i, s := range l.steps {
+		scope.Define(s.name, values[i])
+	}
+
+	m.push(doTestFrame{loop: l, scope: scope})
+	m.eval(l.clause.Subtrees[0], scope)
+	return nil
+}
+
+// doTestFrame - finishes the loop with the result expressions
+// when the test is true, evaluates commands otherwise
+type doTestFrame struct {
+	loop  *loop
+	scope *data.Context
+}
+
+func (f doTestFrame) resume(m *machine, done types.Object) error {
+	if err := single(done); err != nil {
+		return err
+	}
+
+	if types.IsTrue(done) {
+		return sequence(m, f.loop.clause.Subtrees[1:], f.scope)
+	}
+
+	m.push(doStepFrame(f))
+	return sequence(m, f.loop.commands, f.scope)
+}
+
+// doStepFrame - evaluates steps after the commands
+// and starts the next iteration
+type doStepFrame struct {
+	loop  *loop
+	scope *data.Context
+}
+
+func (f doStepFrame) resume(m *machine, _ types.Object) error {
+	exprs := make([]*data.AST, len(f.loop.steps))
+	for i, s := range f.loop.steps {
+		exprs[i] = s.step
+	}
+
+	return evalAll(m, exprs, f.scope, f.loop.iterate)
+}
+
+// doSteps - parses variable specs of the do loop
+func doSteps(ast *data.AST) ([]step, error) {
+	if !ast.IsForm() {
+		return nil, fmt.Errorf("%w: do expects variable list", errscm.ErrBadSyntax)
+	}
+
+	steps := make([]step, len(ast.Subtrees))
+	for i, spec := range ast.Subtrees {
+		n := len(spec.Subtrees)
+		if !spec.IsForm() || n < 2 || n > 3 || spec.Subtrees[0].Kind != data.VariableRef {
+			return nil, fmt.Errorf("%w: do variable must be (name init [step])", errscm.ErrBadSyntax)
+		}
+
+		steps[i].name = spec.Subtrees[0].Identifier()
+		steps[i].init = spec.Subtrees[1]
+		// variables without step keep their current value
+		steps[i].step = spec.Subtrees[0]
+		if n == 3 {
+			steps[i].step = spec.Subtrees[2]
+		}
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,211 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// streamNull - the empty stream
+var streamNull = ready(types.Null)
+
+func init() {
+	core.Register("srfi 41", "stream-null", streamNull)
+	core.Register("srfi 41", "stream-null?", control(isStreamNull))
+	core.Register("srfi 41", "stream-pair?", control(isStreamPair))
+	core.Register("srfi 41", "stream-car", control(streamCar))
+	core.Register("srfi 41", "stream-cdr", control(streamCdr))
+	core.Register("srfi 41", "stream-take", control(streamTake))
+	core.Register("srfi 41", "stream->list", control(streamToList))
+}
+
+// Streams are promises of either the empty list or a pair of
+// the promise of the first element and the stream of the rest
+
+// streamCons - (stream-cons obj stream) creates stream pair
+// of the delayed element and the delayed stream
+func streamCons(ast *data.AST, ctx *data.Context) (types.Object, error) {
+	if len(ast.Subtrees) != 3 {
+		return nil, fmt.Errorf("%w: expected 2 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
+	}
+
+	return ready(types.Cons(
+		&Promise{box: &promiseBox{expr: ast.Subtrees[1], ctx: ctx}},
+		&Promise{box: &promiseBox{expr: ast.Subtrees[2], ctx: ctx, lazy: true}},
+	)), nil
+}
+
+// forceStream - forces the stream and continues with the stream
+// pair, or nil for the empty stream
+func forceStream(m *machine, stream types.Object, then func(*machine, *types.Pair) error) error {
+	if _, ok := stream.(*Promise); !ok {
+		return fmt.Errorf("%w: expected stream, got %v", errscm.ErrUnexpectedType, stream)
+	}
+
+	return forceThen(m, stream, func(m *machine, value types.Object) error {
+		switch v := value.(type) {
+		case *types.Pair:
+			return then(m, v)
+		case *types.EmptyList:
+			return then(m, nil)
+		}
+
+		return fmt.Errorf("%w: expected stream, got promise of %v", errscm.ErrUnexpectedType, value)
+	})
+}
+
+// isStreamNull - `stream-null?` primitive
+func isStreamNull(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return forceStream(m, args[0], func(m *machine, pair *types.Pair) error {
+		m.ret(types.Boolean(pair == nil))
+		return nil
+	})
+}
+
+// isStreamPair - `stream-pair?` primitive
+func isStreamPair(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	if _, ok := args[0].(*Promise); !ok {
+		m.ret(types.Boolean(false))
+		return nil
+	}
+
+	return forceStream(m, args[0], func(m *machine, pair *types.Pair) error {
+		m.ret(types.Boolean(pair != nil))
+		return nil
+	})
+}
+
+// streamCar - `stream-car` primitive: forces the first element
+func streamCar(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return forceStream(m, args[0], func(m *machine, pair *types.Pair) error {
+		if pair == nil {
+			return fmt.Errorf("%w: stream-car of the empty stream", errscm.ErrUnexpectedType)
+		}
+
+		return forceObject(m, pair.Car)
+	})
+}
+
+// streamCdr - `stream-cdr` primitive: returns the rest stream unforced
+func streamCdr(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	return forceStream(m, args[0], func(m *machine, pair *types.Pair) error {
+		if pair == nil {
+			return fmt.Errorf("%w: stream-cdr of the empty stream", errscm.ErrUnexpectedType)
+		}
+
+		m.ret(pair.Cdr)
+		return nil
+	})
+}
+
+// streamTake - `stream-take` primitive: (stream-take n stream)
+// lazily takes first n elements of the stream
+func streamTake(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return err
+	}
+
+	n, err := check.Index(args[0])
+	if err != nil {
+		return err
+	}
+
+	if _, ok := args[1].(*Promise); !ok {
+		return fmt.Errorf("%w: expected stream, got %v", errscm.ErrUnexpectedType, args[1])
+	}
+
+	m.ret(take(n, args[1]))
+	return nil
+}
+
+// take - stream of first n elements of the stream
+func take(n int, stream types.Object) *Promise {
+	if n == 0 {
+		return streamNull
+	}
+
+	thunk := func(m *machine, _ []types.Object) error {
+		return forceStream(m, stream, func(m *machine, pair *types.Pair) error {
+			if pair == nil {
+				m.ret(streamNull)
+				return nil
+			}
+
+			m.ret(ready(types.Cons(pair.Car, take(n-1, pair.Cdr))))
+			return nil
+		})
+	}
+
+	return &Promise{box: &promiseBox{thunk: control(thunk), lazy: true}}
+}
+
+// streamToList - `stream->list` primitive: (stream->list [n] stream)
+// forces the stream, or its first n elements, into a list
+func streamToList(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 2); err != nil {
+		return err
+	}
+
+	n := -1
+	if len(args) == 2 {
+		var err error
+		if n, err = check.Index(args[0]); err != nil {
+			return err
+		}
+	}
+
+	return collect(m, args[len(args)-1], n, types.Null)
+}
+
+// collect - forces elements of the stream one by one, the ones
+// forced so far are kept in the reversed list
+func collect(m *machine, stream types.Object, n int, acc types.Object) error {
+	if n == 0 {
+		return reversed(m, acc)
+	}
+
+	return forceStream(m, stream, func(m *machine, pair *types.Pair) error {
+		if pair == nil {
+			return reversed(m, acc)
+		}
+
+		return forceThen(m, pair.Car, func(m *machine, item types.Object) error {
+			return collect(m, pair.Cdr, n-1, types.Cons(item, acc))
+		})
+	})
+}
+
+// reversed - returns the reversed list
+func reversed(m *machine, list types.Object) error {
+	items, err := types.ListToSlice(list)
+	if err != nil {
+		return err
+	}
+
+	for l, r := 0, len(items)-1; l < r; l, r = l+1, r-1 {
+		items[l], items[r] = items[r], items[l]
+	}
+
+	m.ret(types.List(items...))
+	return nil
+}
@@ -0,0 +1,382 @@
+package interp
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/check"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+func init() {
+	core.Register("gopherscm", "er-macro-transformer", control(erMacroTransformer))
+	core.Register("gopherscm", "identifier?", control(isIdentifier))
+	core.Register("gopherscm", "syntax->datum", control(syntaxToDatum))
+	core.Register("gopherscm", "datum->syntax", control(datumToSyntax))
+}
+
+// Syntax - identifier as a syntax object. The forms are passed to
+// procedural transformers with their identifiers wrapped into syntax
+// objects, which keep the source positions of the identifiers and
+// the contexts the renamed ones are resolved within
+type Syntax struct {
+	id *data.AST
+}
+
+// Value - types.Object interface implementation
+func (s *Syntax) Value() any {
+	return s.id.Name()
+}
+
+func (s *Syntax) String() string {
+	return s.id.Name()
+}
+
+// use - expands the macro use and evaluates the expansion in its place
+func (mac *Macro) use(m *machine, form *data.AST, ctx *data.Context) error {
+	if mac.proc == nil {
+		expanded, err := mac.Expand(form, ctx)
+		if err != nil {
+			return err
+		}
+
+		m.eval(expanded, ctx)
+		return nil
+	}
+
+	obj, err := toData(form, wrap)
+	if err != nil {
+		return err
+	}
+
+	e := newExpansion(mac, form)
+	e.locate(form, obj)
+	m.push(funcFrame(func(m *machine, value types.Object) error {
+		if err := single(value); err != nil {
+			return err
+		}
+
+		expanded, err := e.fromData(value)
+		if err != nil {
+			return err
+		}
+
+		m.eval(expanded, ctx)
+		return nil
+	}))
+
+	return m.apply(mac.proc, []types.Object{obj, control(e.renameProc), e.compareIn(ctx)})
+}
+
+// wrap - wraps identifier into syntax object
+func wrap(id *data.AST) types.Object {
+	return &Syntax{id: id}
+}
+
+// erMacroTransformer - `er-macro-transformer` primitive: creates
+// explicit renaming transformer out of (lambda (form rename compare) ...).
+// The procedure returns the expansion of the form, identifiers
+// it introduces have to be renamed to be hygienic
+func erMacroTransformer(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	if _, ok := args[0].(types.Callable); !ok {
+		return fmt.Errorf("%w: expected procedure, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	m.ret(&Macro{proc: args[0]})
+	return nil
+}
+
+// renameProc - `rename` procedure passed to the transformer:
+// renames the symbol or identifier, so it refers to the binding
+// visible at the macro definition
+func (e *expansion) renameProc(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	name, err := identifierName(args[0])
+	if err != nil {
+		return err
+	}
+
+	m.ret(&Syntax{id: e.rename(e.identifier(name))})
+	return nil
+}
+
+// compareIn - `compare` procedure passed to the transformer used within
+// the context: reports whether both identifiers have the same binding,
+// unbound ones are compared by their names
+func (e *expansion) compareIn(ctx *data.Context) control {
+	return func(m *machine, args []types.Object) error {
+		if err := check.Arity(args, 2, 2); err != nil {
+			return err
+		}
+
+		a, err := e.syntax(args[0])
+		if err != nil {
+			return err
+		}
+
+		b, err := e.syntax(args[1])
+		if err != nil {
+			return err
+		}
+
+		m.ret(types.Boolean(sameBinding(a, ctx, b, ctx)))
+		return nil
+	}
+}
+
+// syntax - identifier of the syntax object, symbols
+// become identifiers of the use site
+func (e *expansion) syntax(obj types.Object) (*data.AST, error) {
+	if _, err := identifierName(obj); err != nil {
+		return nil, err
+	}
+
+	if s, ok := obj.(*Syntax); ok {
+		return s.id, nil
+	}
+
+	return e.identifier(string(obj.(types.Symbol))), nil
+}
+
+// identifierName - name of the symbol or identifier
+func identifierName(obj types.Object) (string, error) {
+	switch id := obj.(type) {
+	case types.Symbol:
+		return string(id), nil
+	case *Syntax:
+		return id.id.Name(), nil
+	}
+
+	return "", fmt.Errorf("%w: expected identifier, got %v", errscm.ErrUnexpectedType, obj)
+}
+
+// identifier - creates identifier placed at the macro use
+func (e *expansion) identifier(name string) *data.AST {
+	return &data.AST{
+		Token:    data.TokenFromMeta(e.form.Token.Meta()).Set(data.Id, []rune(name)...),
+		Kind:     data.VariableRef,
+		Subtrees: make([]*data.AST, 0),
+	}
+}
+
+// fromData - converts the expansion returned by the transformer
+// back into AST. Symbols become identifiers of the use site,
+// forms of the macro use keep their positions, new ones
+// are placed at the position of the macro use
+func (e *expansion) fromData(obj types.Object) (*data.AST, error) {
+	switch v := obj.(type) {
+	case *Syntax:
+		return v.id, nil
+	case types.Symbol:
+		return e.identifier(string(v)), nil
+	case *types.Pair, *types.EmptyList:
+		node := &data.AST{Token: e.token(obj), Subtrees: make([]*data.AST, 0)}
+		for {
+			pair, ok := obj.(*types.Pair)
+			if !ok {
+				break
+			}
+
+			item, err := e.fromData(pair.Car)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Subtrees = append(node.Subtrees, item)
+			obj = pair.Cdr
+		}
+
+		if obj != types.Null {
+			rest, err := e.fromData(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			dot := data.TokenFromMeta(e.form.Token.Meta()).Set(data.Syntax, '.')
+			node.Subtrees = append(node.Subtrees, &data.AST{Token: dot, Kind: data.Dot}, rest)
+		}
+
+		node.Classify()
+		return node, nil
+	case *types.Vector:
+		node := &data.AST{Token: e.token(obj), Kind: data.Vector, Subtrees: make([]*data.AST, 0)}
+		for _, item := range v.Items() {
+			st, err := e.fromData(item)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Subtrees = append(node.Subtrees, st)
+		}
+
+		return node, nil
+	}
+
+	t, err := literalToken(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data.AST{
+		Token:    data.TokenFromMeta(e.form.Token.Meta()).Set(t.Type(), []rune(t.Value())...),
+		Kind:     data.Literal,
+		Subtrees: make([]*data.AST, 0),
+	}, nil
+}
+
+// locate - remembers forms of the macro use converted
+// into the pairs and vectors of the object
+func (e *expansion) locate(ast *data.AST, obj types.Object) {
+	switch v := obj.(type) {
+	case *types.Vector:
+		e.sources[v] = ast
+		for i, item := range v.Items() {
+			e.locate(ast.Subtrees[i], item)
+		}
+	case *types.Pair:
+		e.sources[v] = ast
+		elems, rest, _ := ast.Split()
+		for _, st := range elems {
+			pair := obj.(*types.Pair)
+			e.locate(st, pair.Car)
+			obj = pair.Cdr
+		}
+
+		if rest != nil {
+			e.locate(rest, obj)
+		}
+	}
+}
+
+// token - token of the form the object was converted from,
+// the macro use token for the objects built by the transformer
+func (e *expansion) token(obj types.Object) *data.Token {
+	if ast, ok := e.sources[obj]; ok {
+		return ast.Token
+	}
+
+	return e.form.Token
+}
+
+// literalToken - token of the literal evaluated into the object
+func literalToken(obj types.Object) (*data.Token, error) {
+	switch v := obj.(type) {
+	case *types.Number:
+		if n, ok := v.Value().(int64); ok {
+			return data.NewToken(strconv.FormatInt(n, 10), data.Int), nil
+		}
+
+		return data.NewToken(strconv.FormatFloat(v.Value().(float64), 'f', -1, 64), data.Float), nil
+	case types.String:
+		return data.NewToken(string(v), data.String), nil
+	case types.Boolean:
+		if v {
+			return data.NewToken("t", data.Boolean), nil
+		}
+
+		return data.NewToken("f", data.Boolean), nil
+	case types.Keyword:
+		return data.NewToken(string(v), data.Keyword), nil
+	}
+
+	return nil, fmt.Errorf("%w: %v has no literal syntax", errscm.ErrBadSyntax, obj)
+}
+
+// isIdentifier - `identifier?` primitive
+func isIdentifier(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	_, ok := args[0].(*Syntax)
+	m.ret(types.Boolean(ok))
+	return nil
+}
+
+// syntaxToDatum - `syntax->datum` primitive: replaces identifiers
+// of the syntax object with symbols
+func syntaxToDatum(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 1, 1); err != nil {
+		return err
+	}
+
+	m.ret(strip(args[0]))
+	return nil
+}
+
+// strip - replaces identifiers with symbols
+func strip(obj types.Object) types.Object {
+	switch v := obj.(type) {
+	case *Syntax:
+		return types.Symbol(v.id.Name())
+	case *types.Pair:
+		return types.Cons(strip(v.Car), strip(v.Cdr))
+	case *types.Vector:
+		items := make([]types.Object, len(v.Items()))
+		for i, item := range v.Items() {
+			items[i] = strip(item)
+		}
+
+		return types.NewVector(items...)
+	}
+
+	return obj
+}
+
+// datumToSyntax - `datum->syntax` primitive: (datum->syntax id datum)
+// replaces symbols of the datum with identifiers placed at the
+// identifier and resolved as if they were introduced along with it
+func datumToSyntax(m *machine, args []types.Object) error {
+	if err := check.Arity(args, 2, 2); err != nil {
+		return err
+	}
+
+	id, ok := args[0].(*Syntax)
+	if !ok {
+		return fmt.Errorf("%w: expected identifier, got %v", errscm.ErrUnexpectedType, args[0])
+	}
+
+	m.ret(dress(id.id, args[1]))
+	return nil
+}
+
+// dress - replaces symbols with identifiers sharing
+// the position and context of the template identifier
+func dress(tmpl *data.AST, obj types.Object) types.Object {
+	switch v := obj.(type) {
+	case types.Symbol:
+		id := &data.AST{
+			Token:    data.TokenFromMeta(tmpl.Token.Meta()).Set(data.Id, []rune(v)...),
+			Kind:     data.VariableRef,
+			Subtrees: make([]*data.AST, 0),
+		}
+
+		if tmpl.Origin != nil {
+			renames++
+			id = id.Rename(string(v)+"#"+strconv.Itoa(renames), tmpl.Ctx)
+		}
+
+		return &Syntax{id: id}
+	case *types.Pair:
+		return types.Cons(dress(tmpl, v.Car), dress(tmpl, v.Cdr))
+	case *types.Vector:
+		items := make([]types.Object, len(v.Items()))
+		for i, item := range v.Items() {
+			items[i] = dress(tmpl, item)
+		}
+
+		return types.NewVector(items...)
+	}
+
+	return obj
+}
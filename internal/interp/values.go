@@ -0,0 +1,175 @@
+package interp
+
+import (
+	"fmt"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+)
+
+// valuesFormals - parameter list that multiple values are bound to,
+// it follows the rules of lambda parameter lists
+type valuesFormals struct {
+	*Func
+}
+
+// valueFormals - parses formals of multiple values binding form
+func valueFormals(ast *data.AST, ctx *data.Context, form string) (valuesFormals, error) {
+	fn, err := newFunc(ast, nil, ctx, false)
+	if err != nil {
+		return valuesFormals{}, err
+	}
+
+	fn.Name = form
+	return valuesFormals{fn}, nil
+}
+
+// bind - binds values of the object within the context
+func (f valuesFormals) bind(ctx *data.Context, obj types.Object) error {
+	values := types.ValuesOf(obj)
+	if a := f.arity(); !a.Accepts(len(values)) {
+		if a.Max < 0 {
+			return fmt.Errorf("%w: %s expected at least %d values, got %d", errscm.ErrWrongNumberOfValues, f.Name, a.Min, len(values))
+		}
+
+		return fmt.Errorf("%w: %s expected %d values, got %d", errscm.ErrWrongNumberOfValues, f.Name, a.Min, len(values))
+	}
+
+	_, err := f.bindTo(ctx, values)
+	return err
+}
+
+// valuesBinding - formals with the initialiser
+type valuesBinding struct {
+	formals valuesFormals
+	init    *data.AST
+}
+
+// letValues - (let-values ((formals init) ...) body...) evaluates
+// initialisers within the current context and binds their values
+// within a new scope. let*-values evaluates each initialiser within
+// the scope of the previous bindings
+func letValues(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 3 {
+		return fmt.Errorf("%w: missing %s body", errscm.ErrTooLittleArguments, ast.Head())
+	}
+
+	list := ast.Subtrees[1]
+	if !list.IsForm() {
+		return fmt.Errorf("%w: %s expects binding list", errscm.ErrBadSyntax, ast.Head())
+	}
+
+	bs := make([]valuesBinding, len(list.Subtrees))
+	for i, b := range list.Subtrees {
+		if !b.IsForm() || len(b.Subtrees) != 2 {
+			return fmt.Errorf("%w: %s binding must be (formals init)", errscm.ErrBadSyntax, ast.Head())
+		}
+
+		f, err := valueFormals(b.Subtrees[0], ctx, ast.Head())
+		if err != nil {
+			return err
+		}
+
+		bs[i] = valuesBinding{formals: f, init: b.Subtrees[1]}
+	}
+
+	return letValuesBinding(m, letValuesFrame{
+		bs:         bs,
+		body:       ast.Subtrees[2:],
+		ctx:        ctx,
+		scope:      ctx.Spawn(),
+		sequential: ast.Kind == data.LetStarValuesExpr,
+	})
+}
+
+// letValuesFrame - binds values of the first binding
+type letValuesFrame struct {
+	bs   []valuesBinding
+	body []*data.AST
+	// ctx - context of the initialisers, scope - of the bindings
+	ctx        *data.Context
+	scope      *data.Context
+	sequential bool
+}
+
+// letValuesBinding - evaluates initialiser of the first binding,
+// the body is evaluated after the last one
+func letValuesBinding(m *machine, f letValuesFrame) error {
+	if len(f.bs) == 0 {
+		return sequence(m, f.body, f.scope.Spawn())
+	}
+
+	initCtx := f.ctx
+	if f.sequential {
+		initCtx = f.scope
+	}
+
+	m.push(f)
+	m.eval(f.bs[0].init, initCtx)
+	return nil
+}
+
+func (f letValuesFrame) resume(m *machine, values types.Object) error {
+	scope := f.scope
+	if f.sequential {
+		scope = scope.Spawn()
+	}
+
+	if err := f.bs[0].formals.bind(scope, values); err != nil {
+		return err
+	}
+
+	f.bs, f.scope = f.bs[1:], scope
+	return letValuesBinding(m, f)
+}
+
+// defineValues - (define-values formals expr) defines variables
+// of formals within the current context
+func defineValues(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) != 3 {
+		return fmt.Errorf("%w: expected 2 args, got: %d", errscm.ErrUnexpectedNumberOfArguments, len(ast.Subtrees)-1)
+	}
+
+	f, err := valueFormals(ast.Subtrees[1], ctx, ast.Head())
+	if err != nil {
+		return err
+	}
+
+	m.push(receiveFrame{formals: f, scope: ctx})
+	m.eval(ast.Subtrees[2], ctx)
+	return nil
+}
+
+// receive - (receive formals expr body...) binds values of the
+// expression within a new scope, where the body is evaluated
+func receive(m *machine, ast *data.AST, ctx *data.Context) error {
+	if len(ast.Subtrees) < 4 {
+		return fmt.Errorf("%w: receive expects formals, expression and body", errscm.ErrBadSyntax)
+	}
+
+	f, err := valueFormals(ast.Subtrees[1], ctx, ast.Head())
+	if err != nil {
+		return err
+	}
+
+	m.push(receiveFrame{formals: f, scope: ctx.Spawn(), body: ast.Subtrees[3:]})
+	m.eval(ast.Subtrees[2], ctx)
+	return nil
+}
+
+// receiveFrame - binds the values within the scope and evaluates
+// the body there, define-values has no body
+type receiveFrame struct {
+	formals valuesFormals
+	scope   *data.Context
+	body    []*data.AST
+}
+
+func (f receiveFrame) resume(m *machine, values types.Object) error {
+	if err := f.formals.bind(f.scope, values); err != nil {
+		return err
+	}
+
+	return sequence(m, f.body, f.scope)
+}
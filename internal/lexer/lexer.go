@@ -2,6 +2,7 @@ package lexer
 
 import (
 	"errors"
+	"strings"
 	"unicode"
 
 	"github.com/Vallghall/gopherscm/internal/errscm"
@@ -11,8 +12,18 @@ import (
 
 // Lex transforms input slice of symbols into slice of valid Scheme tokens
 func Lex(src []rune) (data.TokenStream, error) {
+	return lex(src, data.NewMeta())
+}
+
+// LexFile - Lex for the source of the file, positions
+// of the tokens refer to the file
+func LexFile(src []rune, file string) (data.TokenStream, error) {
+	return lex(src, data.NewFileMeta(file))
+}
+
+// lex - transforms the source into tokens positioned by the meta
+func lex(src []rune, m *data.Meta) (data.TokenStream, error) {
 	ts := make(data.TokenStream, 0)
-	m := data.NewMeta()
 	inputLength := len(src)
 	cursor := 0
 
@@ -39,9 +50,10 @@ func Lex(src []rune) (data.TokenStream, error) {
 
 		ts = append(ts, token)
 		if token.Type() == data.Syntax {
-			if token.Value() == "(" {
+			switch token.Value() {
+			case "(", "#(", "#u8(":
 				parenCount++
-			} else {
+			case ")":
 				parenCount--
 			}
 		}
@@ -73,13 +85,47 @@ func Tokenize(cursor int, src []rune, m *data.Meta) (int, *data.Token, error) {
 		return cursor + 1, t.Set(data.Quote, sym), nil
 	}
 
-	// '(' and ')' are the only syntax tokens
+	// '#(' opens a vector literal
+	if sym == '#' && cursor+1 < len(src) && src[cursor+1] == '(' {
+		t := data.TokenFromMeta(m)
+		m.Inc()
+		m.Inc()
+		return cursor + 2, t.Set(data.Syntax, sym, '('), nil
+	}
+
+	// '#u8(' opens a bytevector literal
+	if sym == '#' && hasPrefix(src[cursor:], "#u8(") {
+		t := data.TokenFromMeta(m)
+		for range "#u8(" {
+			m.Inc()
+		}
+		return cursor + 4, t.Set(data.Syntax, src[cursor:cursor+4]...), nil
+	}
+
+	// '#:name' keywords and '#!optional', '#!key', '#!rest' markers
+	if sym == '#' && cursor+1 < len(src) && (src[cursor+1] == ':' || src[cursor+1] == '!') {
+		return extractPrefixed(cursor, src, m)
+	}
+
+	// boolean literals #t, #f, #true and #false
+	if sym == '#' {
+		return extractBoolean(cursor, src, m)
+	}
+
+	// '(' and ')' are the only other syntax tokens
 	if sym == '(' || sym == ')' {
 		t := data.TokenFromMeta(m)
 		m.Inc()
 		return cursor + 1, t.Set(data.Syntax, sym), nil
 	}
 
+	// standalone '.' separates the last element of an improper list
+	if sym == '.' && (cursor+1 >= len(src) || isDelimiter(src[cursor+1]) || src[cursor+1] == '(') {
+		t := data.TokenFromMeta(m)
+		m.Inc()
+		return cursor + 1, t.Set(data.Syntax, sym), nil
+	}
+
 	// parsing string literal like "foo"
 	if sym == '"' {
 		return extractString(cursor, src, m)
@@ -91,8 +137,9 @@ func Tokenize(cursor int, src []rune, m *data.Meta) (int, *data.Token, error) {
 		return extractNumber(cursor, src, m)
 	}
 
-	// Check for an identifier
-	if isValidChar(sym) {
+	// Check for an identifier, the ellipsis `...` is the only
+	// one allowed to start with a dot
+	if isValidChar(sym) || hasPrefix(src[cursor:], "...") {
 		return extractIdentifier(cursor, src, m)
 	}
 
@@ -121,12 +168,11 @@ func skipSingleLineComment(cursor int, src []rune, m *data.Meta) int {
 func skipWhiteSpaces(cursor int, src []rune, m *data.Meta) int {
 	inputLength := len(src)
 	for unicode.IsSpace(src[cursor]) {
+		m.IncNL(src[cursor])
 		cursor++
 		if cursor >= inputLength {
 			return cursor
 		}
-
-		m.IncNL(src[cursor])
 	}
 
 	return cursor
@@ -170,18 +216,19 @@ func extractNumber(cursor int, src []rune, m *data.Meta) (int, *data.Token, erro
 
 	number := []rune{src[cursor]}
 	cursor++
-	if cursor >= len(src) {
-		return cursor, nil, errscm.ErrEndOfInput
-	}
+	m.Inc()
+
+	if number[0] == '-' && (cursor >= len(src) || !unicode.IsDigit(src[cursor])) {
+		// lone minus is the subtraction identifier
+		if cursor >= len(src) || isDelimiter(src[cursor]) {
+			return cursor, t.Set(data.Id, number...), nil
+		}
 
-	// check situations like -foo or -"foo"
-	if number[0] == '-' && !unicode.IsDigit(src[cursor]) && src[cursor-2] != '(' {
+		// situations like -foo or -"foo"
 		return cursor - 1, nil, errscm.ErrNaN
 	}
 
-	m.Inc()
-
-	for unicode.IsDigit(src[cursor]) || src[cursor] == '.' {
+	for cursor < len(src) && (unicode.IsDigit(src[cursor]) || src[cursor] == '.') {
 		number = append(number, src[cursor])
 		if src[cursor] == '.' {
 			if isFloat {
@@ -191,19 +238,10 @@ func extractNumber(cursor int, src []rune, m *data.Meta) (int, *data.Token, erro
 		}
 
 		cursor++
-		if cursor >= len(src) {
-			return cursor, nil, errscm.ErrEndOfInput
-		}
-
 		m.Inc()
 	}
 
-	cursor = skipSingleLineComment(cursor, src, m)
-	if cursor >= len(src) {
-		return cursor, nil, errscm.ErrEndOfInput
-	}
-
-	if sym := src[cursor]; !(unicode.IsSpace(sym) || sym == ')') {
+	if cursor < len(src) && !isDelimiter(src[cursor]) {
 		return cursor, nil, errscm.ErrInvalidNumericLiteral
 	}
 
@@ -218,29 +256,104 @@ func extractIdentifier(cursor int, src []rune, m *data.Meta) (int, *data.Token,
 	t := data.TokenFromMeta(m)
 	id := []rune{src[cursor]}
 	cursor++
-	if cursor >= len(src) {
-		return cursor, nil, errscm.ErrEndOfInput
-	}
-	m.IncNL(src[cursor])
+	m.Inc()
 
-	for isValidChar(src[cursor]) || unicode.IsDigit(src[cursor]) {
+	for cursor < len(src) && (isValidChar(src[cursor]) || unicode.IsDigit(src[cursor]) || src[cursor] == '.') {
 		id = append(id, src[cursor])
 
 		cursor++
-		if cursor >= len(src) {
-			return cursor, nil, errscm.ErrEndOfInput
-		}
-		m.IncNL(src[cursor])
+		m.Inc()
+	}
+
+	// trailing colon makes a keyword like `name:`, identifiers
+	// made of colons only, e.g. `:::`, stay identifiers
+	if n := len(id); n > 1 && id[n-1] == ':' && strings.Trim(string(id), ":") != "" {
+		return cursor, t.Set(data.Keyword, id[:n-1]...), nil
 	}
 
 	return cursor, t.Set(data.Id, id...), nil
 }
 
+// extractPrefixed - helper func for lexing '#:name' keywords
+// and '#!name' parameter list markers, the latter are lexed
+// as identifiers including their prefix
+func extractPrefixed(cursor int, src []rune, m *data.Meta) (int, *data.Token, error) {
+	t := data.TokenFromMeta(m)
+	start := cursor
+	cursor += 2
+	m.Inc()
+	m.Inc()
+
+	for cursor < len(src) && (isValidChar(src[cursor]) || unicode.IsDigit(src[cursor])) {
+		cursor++
+		m.Inc()
+	}
+
+	if cursor == start+2 {
+		return cursor, nil, errscm.ErrInvalidSymbol
+	}
+
+	if src[start+1] == ':' {
+		return cursor, t.Set(data.Keyword, src[start+2:cursor]...), nil
+	}
+
+	return cursor, t.Set(data.Id, src[start:cursor]...), nil
+}
+
+// extractBoolean - helper func for lexing boolean literals
+func extractBoolean(cursor int, src []rune, m *data.Meta) (int, *data.Token, error) {
+	t := data.TokenFromMeta(m)
+	start := cursor
+	cursor++
+	m.Inc()
+
+	for cursor < len(src) && unicode.IsLetter(src[cursor]) {
+		cursor++
+		m.Inc()
+	}
+
+	if cursor < len(src) && !isDelimiter(src[cursor]) {
+		return cursor, nil, errscm.ErrInvalidSymbol
+	}
+
+	switch lit := string(src[start:cursor]); lit {
+	case "#t", "#true":
+		return cursor, t.Set(data.Boolean, 't'), nil
+	case "#f", "#false":
+		return cursor, t.Set(data.Boolean, 'f'), nil
+	}
+
+	return cursor, nil, errscm.ErrInvalidSymbol
+}
+
+// hasPrefix - checks that the rune sequence starts with the given prefix
+func hasPrefix(src []rune, prefix string) bool {
+	pr := []rune(prefix)
+	if len(src) < len(pr) {
+		return false
+	}
+
+	for i, sym := range pr {
+		if src[i] != sym {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDelimiter - predicate for symbols that may terminate a literal
+func isDelimiter(sym rune) bool {
+	return unicode.IsSpace(sym) || sym == ')' || sym == ';'
+}
+
 // isValidChar - predicate for checking a valid identifier's symbol
 func isValidChar(sym rune) bool {
 	return unicode.IsLetter(sym) ||
 		sym == '?' || sym == '!' ||
 		sym == '-' || sym == '_' ||
 		sym == '+' || sym == '*' ||
-		sym == '/'
+		sym == '/' || sym == '<' ||
+		sym == '>' || sym == '=' ||
+		sym == ':'
 }
@@ -5,8 +5,10 @@ import (
 )
 
 const (
-	lParen = "("
-	rParen = ")"
+	lParen   = "("
+	rParen   = ")"
+	vecParen = "#("
+	bvParen  = "#u8("
 )
 
 // Parse - parsing token stream into AST
@@ -20,24 +22,47 @@ func Parse(ts data.TokenStream) *data.AST {
 // parse - recursive helper called from Parse
 func parse(ast *data.AST, ts data.TokenStream, idx int) int {
 	for idx < len(ts) {
-		token := ts[idx]
-		if token.Type() == data.Syntax {
-			if token.Value() == lParen {
-				idx++
-				subtree := ast.Nest(ts[idx])
-				idx = parse(subtree, ts, idx+1)
-				continue
-			}
-
-			if token.Value() == rParen {
-				return idx + 1
-			}
-
+		if isClosing(ts[idx]) {
+			return idx + 1
 		}
 
-		idx++
-		ast.Add(token)
+		idx = parseDatum(ast, ts, idx)
 	}
 
 	return idx
 }
+
+// parseDatum - parses the datum starting at idx into a subtree
+// of the node, returns index of the token following the datum
+func parseDatum(ast *data.AST, ts data.TokenStream, idx int) int {
+	token := ts[idx]
+	switch {
+	case token.Type() == data.Syntax && token.Value() == lParen:
+		subtree := ast.Nest(token)
+		idx = parse(subtree, ts, idx+1)
+		subtree.Classify()
+		return idx
+	case token.Type() == data.Syntax && (token.Value() == vecParen || token.Value() == bvParen):
+		subtree := ast.NestVector(token)
+		return parse(subtree, ts, idx+1)
+	case token.Type() == data.Quote:
+		// 'datum is a shorthand for (quote datum)
+		subtree := ast.Nest(data.TokenFromMeta(token.Meta()).Set(data.Syntax, '('))
+		subtree.Add(data.TokenFromMeta(token.Meta()).Set(data.Id, []rune("quote")...))
+		idx++
+		if idx < len(ts) && !isClosing(ts[idx]) {
+			idx = parseDatum(subtree, ts, idx)
+		}
+
+		subtree.Classify()
+		return idx
+	}
+
+	ast.Add(token)
+	return idx + 1
+}
+
+// isClosing - reports whether the token is the closing parenthesis
+func isClosing(token *data.Token) bool {
+	return token.Type() == data.Syntax && token.Value() == rParen
+}
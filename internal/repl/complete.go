@@ -0,0 +1,92 @@
+package repl
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Complete - completion of the line editor: file names inside
+// strings, identifiers bound within the session elsewhere
+func (r *REPL) Complete(line []rune, pos int) (int, []string) {
+	if quote := openString(line[:pos]); quote >= 0 {
+		return completePath(line, quote+1, pos)
+	}
+
+	start := pos
+	for start > 0 && !isDelimiter(line[start-1]) {
+		start--
+	}
+
+	prefix := string(line[start:pos])
+	if prefix == "" {
+		return pos, nil
+	}
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for ctx := r.ctx; ctx != nil; ctx = ctx.Outer() {
+		for _, name := range ctx.Names() {
+			if strings.HasPrefix(name, prefix) && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return start, names
+}
+
+// isDelimiter - reports whether the rune ends an identifier
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()"';`+"`,", r)
+}
+
+// openString - returns index of the quote opening the string
+// the line ends within, -1 if it does not end within one
+func openString(line []rune) int {
+	quote := -1
+	for i := 0; i < len(line); i++ {
+		switch {
+		case quote >= 0 && line[i] == '\\':
+			i++
+		case line[i] == '"' && quote >= 0:
+			quote = -1
+		case line[i] == '"':
+			quote = i
+		case line[i] == ';' && quote < 0:
+			return -1
+		}
+	}
+
+	return quote
+}
+
+// completePath - completes the file name between start and pos,
+// directories are completed with the trailing separator
+func completePath(line []rune, start, pos int) (int, []string) {
+	dir, base := filepath.Split(string(line[start:pos]))
+	entries, err := os.ReadDir(filepath.Clean(dir + "."))
+	if err != nil {
+		return pos, nil
+	}
+
+	names := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) || strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+
+		if entry.IsDir() {
+			name += string(filepath.Separator)
+		}
+
+		names = append(names, name)
+	}
+
+	return start + len([]rune(dir)), names
+}
@@ -0,0 +1,393 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxHistory - number of the lines kept in the history
+const maxHistory = 1000
+
+// ErrInterrupted - the line was discarded with Ctrl-C
+var ErrInterrupted = errors.New("interrupted")
+
+// Editor - line editor reading keystrokes of a terminal in raw mode.
+// Supports cursor movement with arrows and Ctrl-A/E/B/F, killing with
+// Ctrl-K/U, history navigation with Up/Down and Ctrl-P/N, reverse
+// history search with Ctrl-R and tab completion
+type Editor struct {
+	in  *bufio.Reader
+	out io.Writer
+	// term - terminal switched into raw mode while
+	// a line is read, nil for other inputs
+	term *os.File
+	// history - entered lines, the oldest first
+	history []string
+	// historyFile - file the entered lines are appended to
+	historyFile string
+	// Complete - returns candidates replacing the word before the
+	// cursor at pos, along with the position the word starts at
+	Complete func(line []rune, pos int) (int, []string)
+}
+
+// NewEditor - Editor constructor, the input is switched into
+// raw mode while reading lines if it is a terminal
+func NewEditor(in io.Reader, out io.Writer) *Editor {
+	e := &Editor{in: bufio.NewReader(in), out: out}
+	if f, ok := in.(*os.File); ok && isTerminal(f) {
+		e.term = f
+	}
+
+	return e
+}
+
+// LoadHistory - loads the history from the file, the lines
+// entered later are appended to it. A missing file is
+// created with the first entered line
+func (e *Editor) LoadHistory(path string) error {
+	e.historyFile = path
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(string(bs), "\n") {
+		if line != "" {
+			e.history = append(e.history, line)
+		}
+	}
+
+	if len(e.history) > maxHistory {
+		e.history = e.history[len(e.history)-maxHistory:]
+		return os.WriteFile(path, []byte(strings.Join(e.history, "\n")+"\n"), 0o600)
+	}
+
+	return nil
+}
+
+// History - returns the entered lines, the oldest first
+func (e *Editor) History() []string {
+	return e.history
+}
+
+// remember - adds the line to the history and the history
+// file, repeating the last one or blank lines are skipped
+func (e *Editor) remember(line string) {
+	if strings.TrimSpace(line) == "" || len(e.history) > 0 && e.history[len(e.history)-1] == line {
+		return
+	}
+
+	e.history = append(e.history, line)
+	if len(e.history) > maxHistory {
+		e.history = e.history[1:]
+	}
+
+	if e.historyFile == "" {
+		return
+	}
+
+	f, err := os.OpenFile(e.historyFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, line)
+}
+
+// ctrl - code of the key pressed with Ctrl
+func ctrl(key rune) rune {
+	return key & 0x1f
+}
+
+// line - state of the line being edited
+type line struct {
+	e      *Editor
+	prompt string
+	buf    []rune
+	pos    int
+	// index - position in the history, the length
+	// of the history stands for the edited line
+	index int
+	// draft - the edited line kept while browsing the history
+	draft []rune
+	// searching - reverse history search is on, query
+	// is searched for and match is the found entry
+	searching bool
+	query     []rune
+	match     int
+}
+
+// ReadLine - reads the line showing the prompt. Returns io.EOF on
+// Ctrl-D at the empty line or at the end of the input along with
+// the line read so far, ErrInterrupted on Ctrl-C
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if e.term != nil {
+		if restore, err := makeRaw(e.term); err == nil {
+			defer restore()
+		}
+	}
+
+	l := &line{e: e, prompt: prompt, index: len(e.history)}
+	fmt.Fprint(e.out, prompt)
+	for {
+		key, _, err := e.in.ReadRune()
+		if err != nil {
+			return string(l.buf), err
+		}
+
+		if l.searching {
+			key = l.search(key)
+		}
+
+		switch key {
+		case 0:
+			// consumed by the search
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			e.remember(string(l.buf))
+			return string(l.buf), nil
+		case ctrl('C'):
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case ctrl('D'):
+			if len(l.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+
+			l.delete()
+		case ctrl('A'):
+			l.pos = 0
+		case ctrl('E'):
+			l.pos = len(l.buf)
+		case ctrl('B'):
+			l.move(-1)
+		case ctrl('F'):
+			l.move(1)
+		case ctrl('H'), 0x7f:
+			if l.pos > 0 {
+				l.pos--
+				l.delete()
+			}
+		case ctrl('K'):
+			l.buf = l.buf[:l.pos]
+		case ctrl('U'):
+			l.buf = append([]rune{}, l.buf[l.pos:]...)
+			l.pos = 0
+		case ctrl('P'):
+			l.browse(-1)
+		case ctrl('N'):
+			l.browse(1)
+		case ctrl('R'):
+			l.searching, l.query, l.match = true, nil, len(e.history)
+		case '\t':
+			l.complete()
+		case 0x1b:
+			l.escape()
+		default:
+			if key >= ' ' {
+				l.insert([]rune{key})
+			}
+		}
+
+		l.redraw()
+	}
+}
+
+// escape - handles escape sequences of the arrow, Home, End and Delete keys
+func (l *line) escape() {
+	next, _, err := l.e.in.ReadRune()
+	if err != nil || next != '[' && next != 'O' {
+		return
+	}
+
+	key, _, err := l.e.in.ReadRune()
+	if err != nil {
+		return
+	}
+
+	// sequences like ESC [ 3 ~ carry a number
+	num := ""
+	for key >= '0' && key <= '9' {
+		num += string(key)
+		if key, _, err = l.e.in.ReadRune(); err != nil {
+			return
+		}
+	}
+
+	switch {
+	case key == 'A':
+		l.browse(-1)
+	case key == 'B':
+		l.browse(1)
+	case key == 'C':
+		l.move(1)
+	case key == 'D':
+		l.move(-1)
+	case key == 'H' || key == '~' && (num == "1" || num == "7"):
+		l.pos = 0
+	case key == 'F' || key == '~' && (num == "4" || num == "8"):
+		l.pos = len(l.buf)
+	case key == '~' && num == "3":
+		l.delete()
+	}
+}
+
+// move - moves the cursor within the line
+func (l *line) move(by int) {
+	if pos := l.pos + by; pos >= 0 && pos <= len(l.buf) {
+		l.pos = pos
+	}
+}
+
+// insert - inserts the runes at the cursor
+func (l *line) insert(rs []rune) {
+	buf := make([]rune, 0, len(l.buf)+len(rs))
+	buf = append(buf, l.buf[:l.pos]...)
+	buf = append(buf, rs...)
+	l.buf = append(buf, l.buf[l.pos:]...)
+	l.pos += len(rs)
+}
+
+// delete - deletes the rune under the cursor
+func (l *line) delete() {
+	if l.pos < len(l.buf) {
+		l.buf = append(l.buf[:l.pos], l.buf[l.pos+1:]...)
+	}
+}
+
+// browse - replaces the line with the previous or the next
+// history entry, the edited line is kept as a draft
+func (l *line) browse(by int) {
+	index := l.index + by
+	if index < 0 || index > len(l.e.history) {
+		return
+	}
+
+	if l.index == len(l.e.history) {
+		l.draft = l.buf
+	}
+
+	l.index = index
+	if index == len(l.e.history) {
+		l.buf = l.draft
+	} else {
+		l.buf = []rune(l.e.history[index])
+	}
+
+	l.pos = len(l.buf)
+}
+
+// search - handles the key during the reverse history search.
+// Typed keys extend the query, Ctrl-R looks for an older match,
+// Ctrl-G cancels the search, the other keys accept the match
+// and are handled as usual. Returns 0 for the keys consumed
+// by the search
+func (l *line) search(key rune) rune {
+	switch {
+	case key == ctrl('R'):
+		l.find(l.match - 1)
+		return 0
+	case key == ctrl('G'):
+		l.searching = false
+		return 0
+	case key == ctrl('H') || key == 0x7f:
+		if len(l.query) > 0 {
+			l.query = l.query[:len(l.query)-1]
+			l.find(len(l.e.history) - 1)
+		}
+
+		return 0
+	case key >= ' ' && key != 0x7f:
+		l.query = append(l.query, key)
+		l.find(l.match)
+		return 0
+	}
+
+	l.searching = false
+	if l.match < len(l.e.history) {
+		l.buf = []rune(l.e.history[l.match])
+		l.pos = len(l.buf)
+	}
+
+	return key
+}
+
+// find - looks for the history entry containing
+// the query, starting from the given one back
+func (l *line) find(from int) {
+	if from >= len(l.e.history) {
+		from = len(l.e.history) - 1
+	}
+
+	for i := from; i >= 0; i-- {
+		if strings.Contains(l.e.history[i], string(l.query)) {
+			l.match = i
+			return
+		}
+	}
+}
+
+// complete - replaces the word before the cursor with the common
+// prefix of the candidates, lists them if it can not be extended
+func (l *line) complete() {
+	if l.e.Complete == nil {
+		return
+	}
+
+	start, candidates := l.e.Complete(l.buf, l.pos)
+	if len(candidates) == 0 {
+		fmt.Fprint(l.e.out, "\a")
+		return
+	}
+
+	prefix := []rune(candidates[0])
+	for _, c := range candidates[1:] {
+		rs := []rune(c)
+		n := 0
+		for n < len(prefix) && n < len(rs) && prefix[n] == rs[n] {
+			n++
+		}
+
+		prefix = prefix[:n]
+	}
+
+	if len(prefix) > l.pos-start {
+		rest := append([]rune{}, l.buf[l.pos:]...)
+		l.buf = append(l.buf[:start], prefix...)
+		l.pos = len(l.buf)
+		l.buf = append(l.buf, rest...)
+		return
+	}
+
+	if len(candidates) > 1 {
+		fmt.Fprintf(l.e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+	}
+}
+
+// redraw - rewrites the line and places the cursor
+func (l *line) redraw() {
+	if l.searching {
+		found := ""
+		if l.match < len(l.e.history) {
+			found = l.e.history[l.match]
+		}
+
+		fmt.Fprintf(l.e.out, "\r(reverse-i-search)`%s': %s\x1b[K", string(l.query), found)
+		return
+	}
+
+	fmt.Fprintf(l.e.out, "\r%s%s\x1b[K", l.prompt, string(l.buf))
+	if back := len(l.buf) - l.pos; back > 0 {
+		fmt.Fprintf(l.e.out, "\x1b[%dD", back)
+	}
+}
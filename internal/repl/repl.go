@@ -0,0 +1,259 @@
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Vallghall/gopherscm/internal/core"
+	"github.com/Vallghall/gopherscm/internal/core/stdio"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/interp"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/Vallghall/gopherscm/internal/parser"
+)
+
+const (
+	// prompt - prompt of the first line of the input
+	prompt = "gopherscm> "
+	// continuation - prompt of the following lines
+	// of the input with unbalanced parentheses
+	continuation = "... "
+)
+
+// commands - meta-commands with their descriptions
+var commands = [][2]string{
+	{",help", "shows this help"},
+	{",load <file>", "evaluates the file within the session"},
+	{",env", "lists the definitions of the session"},
+	{",time <expr>", "evaluates the expression and reports the time it took"},
+	{",quit", "exits the REPL"},
+}
+
+// historyFile - name of the history file in the home directory
+const historyFile = ".gopherscm_history"
+
+// lineReader - source of the input lines
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// plainReader - reads lines of the input that is not a terminal
+type plainReader struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ReadLine - lineReader interface implementation
+func (p *plainReader) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+	line, err := p.in.ReadString('\n')
+	return strings.TrimSuffix(line, "\n"), err
+}
+
+// REPL - read-eval-print loop evaluating the input
+// within one persistent context
+type REPL struct {
+	lines lineReader
+	out   io.Writer
+	// ctx - context of the session, spawned from
+	// the builtins to list the definitions apart
+	ctx *data.Context
+}
+
+// New - REPL constructor. Terminal input is read with the line
+// editor, which keeps the history in the home directory and
+// completes identifiers and file names
+func New(in io.Reader, out io.Writer) *REPL {
+	r := &REPL{
+		lines: &plainReader{in: bufio.NewReader(in), out: out},
+		out:   out,
+		ctx:   data.NewContext(core.DefaultDefinitions()).Spawn(),
+	}
+
+	if f, ok := in.(*os.File); ok && isTerminal(f) {
+		ed := NewEditor(in, out)
+		ed.Complete = r.Complete
+		if home, err := os.UserHomeDir(); err == nil {
+			_ = ed.LoadHistory(filepath.Join(home, historyFile))
+		}
+
+		r.lines = ed
+	}
+
+	return r
+}
+
+// Run - reads and evaluates the input until its end or the quit
+// command. Returns *errscm.ExitError if the evaluated code exits
+func (r *REPL) Run() error {
+	interp.SetInteractionEnvironment(r.ctx)
+	fmt.Fprintln(r.out, "gopherscm REPL, type ,help for the list of commands")
+	for {
+		src, ok := r.read()
+		if src = strings.TrimSpace(src); src != "" {
+			if quit, err := r.handle(src); quit || err != nil {
+				return err
+			}
+		}
+
+		if !ok {
+			fmt.Fprintln(r.out)
+			return nil
+		}
+	}
+}
+
+// read - reads lines until the parentheses of the input are
+// balanced, reports false at the end of the input. Input
+// interrupted with Ctrl-C is discarded
+func (r *REPL) read() (string, bool) {
+	var sb strings.Builder
+	p := prompt
+	for {
+		line, err := r.lines.ReadLine(p)
+		if errors.Is(err, ErrInterrupted) {
+			sb.Reset()
+			p = prompt
+			continue
+		}
+
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+		if err != nil {
+			return sb.String(), false
+		}
+
+		if balanced(sb.String()) {
+			return sb.String(), true
+		}
+
+		p = continuation
+	}
+}
+
+// balanced - reports whether the input has no unclosed parentheses,
+// meta-commands are checked without the command name
+func balanced(src string) bool {
+	if src = strings.TrimSpace(src); strings.HasPrefix(src, ",") {
+		_, src, _ = strings.Cut(src, " ")
+	}
+
+	_, err := lexer.Lex([]rune(src))
+	return !errors.Is(err, errscm.ErrMissingClosingParenthesis)
+}
+
+// handle - evaluates the input or runs the meta-command, reports
+// true if the REPL has to quit and the error the code exited with
+func (r *REPL) handle(src string) (bool, error) {
+	if !strings.HasPrefix(src, ",") {
+		return false, r.eval([]rune(src), "", true)
+	}
+
+	name, arg, _ := strings.Cut(src, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case ",help", ",h":
+		for _, cmd := range commands {
+			fmt.Fprintf(r.out, "%-14s %s\n", cmd[0], cmd[1])
+		}
+	case ",load", ",l":
+		if arg == "" {
+			fmt.Fprintln(r.out, "usage: ,load <file>")
+			break
+		}
+
+		path := strings.Trim(arg, `"`)
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(r.out, err)
+			break
+		}
+
+		return false, r.eval(bytes.Runes(bs), path, false)
+	case ",env", ",e":
+		for _, def := range r.ctx.Names() {
+			obj, _ := r.ctx.FindDef(def)
+			fmt.Fprintf(r.out, "%s = %s\n", def, stdio.Written(obj))
+		}
+	case ",time", ",t":
+		start := time.Now()
+		if err := r.eval([]rune(arg), "", true); err != nil {
+			return false, err
+		}
+
+		fmt.Fprintf(r.out, ";; %v\n", time.Since(start))
+	case ",quit", ",q":
+		return true, nil
+	default:
+		fmt.Fprintf(r.out, "unknown command %s, type ,help for the list of commands\n", name)
+	}
+
+	return false, nil
+}
+
+// eval - evaluates the expressions of the source one by one,
+// writing their results if print is set. Evaluation stops
+// at the first error, which is reported to the output.
+// Exiting the program is returned instead
+func (r *REPL) eval(src []rune, file string, print bool) error {
+	ts, err := lexer.LexFile(src, file)
+	if err != nil {
+		fmt.Fprintln(r.out, err)
+		return nil
+	}
+
+	for _, form := range parser.Parse(ts).Subtrees {
+		result, err := r.evalForm(form)
+		var exit *errscm.ExitError
+		if errors.As(err, &exit) {
+			return exit
+		}
+
+		if err != nil {
+			fmt.Fprintln(r.out, err)
+			return nil
+		}
+
+		if print {
+			r.print(result)
+		}
+	}
+
+	return nil
+}
+
+// evalForm - evaluates the top-level form, failures
+// of the evaluator are turned into errors
+func (r *REPL) evalForm(form *data.AST) (result types.Object, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("ERROR: %v", p)
+		}
+	}()
+
+	return interp.Eval(form, r.ctx)
+}
+
+// print - writes the result to the output of the REPL
+func (r *REPL) print(result types.Object) {
+	Print(r.out, result)
+}
+
+// Print - writes the result the way the REPL does,
+// unspecified values and no values are not printed
+func Print(out io.Writer, result types.Object) {
+	if vs, ok := result.(types.Values); result == nil || ok && len(vs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, stdio.Written(result))
+}
@@ -0,0 +1,9 @@
+package repl
+
+import "syscall"
+
+// ioctl requests getting and setting terminal attributes
+const (
+	getTermios = syscall.TIOCGETA
+	setTermios = syscall.TIOCSETA
+)
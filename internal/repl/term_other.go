@@ -0,0 +1,19 @@
+//go:build !linux && !darwin
+
+package repl
+
+import (
+	"errors"
+	"os"
+)
+
+// isTerminal - raw mode is not supported on the platform,
+// the input is read by lines without editing
+func isTerminal(*os.File) bool {
+	return false
+}
+
+// makeRaw - raw mode is not supported on the platform
+func makeRaw(*os.File) (func(), error) {
+	return nil, errors.New("raw mode is not supported")
+}
@@ -0,0 +1,52 @@
+//go:build linux || darwin
+
+package repl
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// termios - gets or sets attributes of the terminal
+func termios(f *os.File, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// isTerminal - reports whether the file is a terminal
+func isTerminal(f *os.File) bool {
+	var t syscall.Termios
+	return termios(f, getTermios, &t) == nil
+}
+
+// makeRaw - switches the terminal into raw mode, where the input
+// is neither echoed nor buffered by lines, and returns function
+// restoring the previous mode. Output processing is kept, so
+// the new lines are still written as they are
+func makeRaw(f *os.File) (func(), error) {
+	var old syscall.Termios
+	if err := termios(f, getTermios, &old); err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(f, setTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_ = termios(f, setTermios, &old)
+	}, nil
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignment(t *testing.T) {
+
+	t.Run("counter closure", func(t *testing.T) {
+		result, err := run(t, `
+(define (make-counter)
+  (let ((n 0))
+    (lambda ()
+      (set! n (+ n 1))
+      n)))
+(define a (make-counter))
+(define b (make-counter))
+(a)
+(a)
+(b)
+(list (a) (b))`)
+		require.NoError(t, err)
+		require.Equal(t, "(3 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("set! mutates the nearest binding", func(t *testing.T) {
+		result, err := run(t, `
+(define x 1)
+(define (shadow x) (set! x 5) x)
+(list (shadow 2) x)`)
+		require.NoError(t, err)
+		require.Equal(t, "(5 1)", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define total 0)
+(define (add! n) (set! total (+ total n)))
+(add! 3)
+(add! 4)
+total`)
+		require.NoError(t, err)
+		require.Equal(t, int64(7), result.Value())
+	})
+
+	t.Run("define creates in the current frame", func(t *testing.T) {
+		result, err := run(t, `
+(define x 1)
+(define (f) (define x 2) x)
+(list (f) x)`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("set! of unbound identifier", func(t *testing.T) {
+		_, err := run(t, `(set! undefined-var 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+
+		_, err = run(t, `(let ((x 1)) (set! y x))`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+}
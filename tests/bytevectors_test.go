@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytevectors(t *testing.T) {
+
+	t.Run("bytevector literal lexing", func(t *testing.T) {
+		ts, err := lexer.Lex([]rune("#u8(1 255)"))
+		require.NoErrorf(t, err, "expected no err, got: %v", err)
+		expected := data.TokenStream{
+			data.NewToken("#u8(", data.Syntax),
+			data.NewToken("1", data.Int),
+			data.NewToken("255", data.Int),
+			data.NewToken(")", data.Syntax),
+		}
+		require.Equal(t, len(expected), len(ts))
+
+		for i, tkn := range ts {
+			require.Equal(t, tkn.Type(), expected[i].Type())
+			require.Equal(t, tkn.Value(), expected[i].Value())
+		}
+	})
+
+	t.Run("bytevector literal evaluation", func(t *testing.T) {
+		result, err := run(t, `#u8(0 16 255)`)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0, 16, 255}, result.Value())
+
+		_, err = run(t, `#u8(1 256)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+
+	t.Run("mutation and copying", func(t *testing.T) {
+		result, err := run(t, `
+(define b (make-bytevector 4 0))
+(bytevector-u8-set! b 0 7)
+(bytevector-copy! b 2 #u8(1 2 3) 1)
+(bytevector-append (bytevector-copy b 0 1) b)`)
+		require.NoError(t, err)
+		require.Equal(t, []byte{7, 7, 0, 2, 3}, result.Value())
+	})
+
+	t.Run("utf8 conversion", func(t *testing.T) {
+		result, err := run(t, `(string->utf8 "привет" 1 3)`)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ри"), result.Value())
+
+		result, err = run(t, `(utf8->string (string->utf8 "héllo"))`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("héllo"), result)
+
+		_, err = run(t, `(utf8->string #u8(255))`)
+		require.ErrorIs(t, err, errscm.ErrInvalidEncoding)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := run(t, `(bytevector-u8-ref #u8(1 2) 2)`)
+		require.ErrorIs(t, err, errscm.ErrIndexOutOfRange)
+
+		result, err := run(t, `
+(guard (e (#t 'too-long))
+  (make-bytevector 100000000000))`)
+		require.NoError(t, err)
+		require.Equal(t, types.Symbol("too-long"), result)
+	})
+
+	t.Run("binary ports", func(t *testing.T) {
+		result, err := run(t, `
+(define in (open-input-bytevector #u8(1 2)))
+(define out (open-output-bytevector))
+(write-u8 (peek-u8 in) out)
+(write-u8 (read-u8 in) out)
+(write-u8 (+ (read-u8 in) 40) out)
+(list (get-output-bytevector out) (eof-object? (read-u8 in)) (eof-object? (peek-u8 in))
+      (eof-object? 0) (eof-object? (eof-object)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#u8(1 1 42) #t #t #f #t)", result.(*types.Pair).String())
+
+		_, err = run(t, `(write-u8 256 (open-output-bytevector))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+
+		_, err = run(t, `(get-output-bytevector (open-output-string))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+
+		_, err = run(t, `(read-u8 (open-output-bytevector))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+}
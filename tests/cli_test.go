@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandLine(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "gopherscm")
+	build := exec.Command("go", "build", "-o", bin, "github.com/Vallghall/gopherscm/cmd/app")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("building the interpreter: %v\n%s", err, out)
+	}
+
+	// gopherscm - runs the interpreter with the arguments and
+	// the standard input, returns its output and exit status
+	gopherscm := func(t *testing.T, stdin string, args ...string) (string, int) {
+		t.Helper()
+
+		cmd := exec.Command(bin, args...)
+		cmd.Stdin = strings.NewReader(stdin)
+		out, err := cmd.Output()
+
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return string(out), exit.ExitCode()
+		}
+
+		require.NoError(t, err)
+		return string(out), 0
+	}
+
+	// script - writes the script into the temporary directory
+	script := func(t *testing.T, name, code string) string {
+		t.Helper()
+
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(code), 0o644))
+		return path
+	}
+
+	t.Run("repeated expressions", func(t *testing.T) {
+		out, status := gopherscm(t, "", "-e", "(define x 20)", "-e", "(+ x 22)")
+		require.Equal(t, 0, status)
+		require.Equal(t, "42\n", out)
+	})
+
+	t.Run("script from the standard input", func(t *testing.T) {
+		out, status := gopherscm(t, `(display "stdin")`, "-")
+		require.Equal(t, 0, status)
+		require.Equal(t, "stdin", out)
+	})
+
+	t.Run("scripts and expressions share the environment", func(t *testing.T) {
+		first := script(t, "first.scm", "(define y 5)")
+		second := script(t, "second.scm", "(display (+ y 1))")
+
+		out, status := gopherscm(t, "", first, second, "-e", "(* y 2)")
+		require.Equal(t, 0, status)
+		require.Equal(t, "610\n", out)
+	})
+
+	t.Run("uncaught error exits with status 1", func(t *testing.T) {
+		failing := script(t, "failing.scm", "(display 1)\n(car 1)\n(display 2)")
+
+		out, status := gopherscm(t, "", failing)
+		require.Equal(t, 1, status)
+		require.Equal(t, "1", out)
+
+		_, status = gopherscm(t, "", "-e", "(car 1)", "-e", "(exit 3)")
+		require.Equal(t, 1, status)
+	})
+
+	t.Run("exit status and program arguments", func(t *testing.T) {
+		out, status := gopherscm(t, "", "-e", "(display (cdr (command-line)))", "-e", "(exit 7)", "--", "a", "b")
+		require.Equal(t, 7, status)
+		require.Equal(t, `(a b)`, out)
+	})
+}
@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/interp"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/Vallghall/gopherscm/internal/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinuations(t *testing.T) {
+
+	t.Run("escape", func(t *testing.T) {
+		result, err := run(t, `(+ 1 (call/cc (lambda (k) (* 10 (k 2)))))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.Value())
+
+		result, err = run(t, `(+ 1 (call-with-current-continuation (lambda (k) 5)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), result.Value())
+	})
+
+	t.Run("multiple values", func(t *testing.T) {
+		result, err := run(t, `(call-with-values (lambda () (call/cc (lambda (k) (k 1 2)))) list)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("re-entry", func(t *testing.T) {
+		result, err := run(t, `
+(let ((k #f) (acc (list)))
+  (let ((v (call/cc (lambda (c) (set! k c) 0))))
+    (set! acc (cons v acc))
+    (if (< v 3) (k (+ v 1)) acc)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(3 2 1 0)", result.(*types.Pair).String())
+	})
+
+	t.Run("re-entry of a top-level expression", func(t *testing.T) {
+		// the continuation ends with its top-level expression,
+		// the evaluation goes on after the one invoking it
+		result, err := run(t, `
+(define k #f)
+(define count 0)
+(define results (list))
+(define r (list 1 (call/cc (lambda (c) (set! k c) 2)) 3))
+(set! results (cons r results))
+(if (= count 0) (begin (set! count 1) (k 20)))
+(list r results)`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 20 3) ((1 2 3)))", result.(*types.Pair).String())
+	})
+
+	t.Run("generator through vector-for-each", func(t *testing.T) {
+		result, err := run(t, `
+(define (make-generator vec)
+  (define return #f)
+  (define resume #f)
+  (lambda ()
+    (call/cc
+      (lambda (r)
+        (set! return r)
+        (if resume
+            (resume #f)
+            (begin
+              (vector-for-each
+                (lambda (x) (call/cc (lambda (k) (set! resume k) (return x))))
+                vec)
+              (return 0)))))))
+(define g (make-generator #(1 2 3)))
+(list (g) (g) (g) (g))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3 0)", result.(*types.Pair).String())
+	})
+
+	t.Run("loop through continuation in constant space", func(t *testing.T) {
+		result, err := run(t, `
+(define (loop n)
+  (if (= n 0)
+      "done"
+      (call/cc (lambda (k) (loop (- n 1))))))
+(loop 100000)`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("done"), result)
+	})
+}
+
+func TestDynamicWind(t *testing.T) {
+	const trace = `
+(define trace (list))
+(define (note x) (set! trace (cons x trace)))
+`
+
+	t.Run("normal return", func(t *testing.T) {
+		result, err := run(t, trace+`
+(define v (dynamic-wind
+  (lambda () (note 1))
+  (lambda () (note 2) 42)
+  (lambda () (note 3))))
+(cons v trace)`)
+		require.NoError(t, err)
+		require.Equal(t, "(42 3 2 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("escape", func(t *testing.T) {
+		result, err := run(t, trace+`
+(call/cc
+  (lambda (k)
+    (dynamic-wind
+      (lambda () (note 1))
+      (lambda () (k 0) (note 2))
+      (lambda () (note 3)))))
+trace`)
+		require.NoError(t, err)
+		require.Equal(t, "(3 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("re-entry", func(t *testing.T) {
+		result, err := run(t, trace+`
+(define k #f)
+(define count 0)
+(dynamic-wind
+  (lambda () (note 1))
+  (lambda () (call/cc (lambda (c) (set! k c))) (note 2))
+  (lambda () (note 3)))
+(if (= count 0) (begin (set! count 1) (k #f)))
+trace`)
+		require.NoError(t, err)
+		require.Equal(t, "(3 2 1 3 2 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("nested extents", func(t *testing.T) {
+		result, err := run(t, trace+`
+(define k #f)
+(define count 0)
+(dynamic-wind
+  (lambda () (note 1))
+  (lambda ()
+    (dynamic-wind
+      (lambda () (note 2))
+      (lambda () (call/cc (lambda (c) (set! k c))))
+      (lambda () (note 3))))
+  (lambda () (note 4)))
+(if (= count 0) (begin (set! count 1) (k #f)))
+trace`)
+		require.NoError(t, err)
+		require.Equal(t, "(4 3 2 1 4 3 2 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ts, err := lexer.Lex([]rune(trace + `
+(dynamic-wind
+  (lambda () (note 1))
+  (lambda () (undefined-procedure))
+  (lambda () (note 2)))`))
+		require.NoError(t, err)
+
+		ast := parser.Parse(ts)
+		_, err = interp.Walk(ast)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+
+		result, ok := ast.Ctx.FindDef("trace")
+		require.True(t, ok)
+		require.Equal(t, "(2 1)", result.(*types.Pair).String())
+	})
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/repl"
+	"github.com/stretchr/testify/require"
+)
+
+// editor - creates line editor reading the keystrokes
+func editor(keys string) *repl.Editor {
+	return repl.NewEditor(strings.NewReader(keys), io.Discard)
+}
+
+// readLines - reads n lines with the editor
+func readLines(t *testing.T, ed *repl.Editor, n int) []string {
+	t.Helper()
+
+	lines := make([]string, n)
+	for i := range lines {
+		line, err := ed.ReadLine("> ")
+		require.NoError(t, err)
+		lines[i] = line
+	}
+
+	return lines
+}
+
+func TestEditor(t *testing.T) {
+
+	t.Run("cursor movement and killing", func(t *testing.T) {
+		ed := editor("abc\x01X\x05Y\r" + "abcd\x1b[D\x1b[D\x0b\r" + "abcd\x02\x02\x15\r" + "ab\x7fc\x1b[H\x1b[3~\r")
+		require.Equal(t, []string{"XabcY", "ab", "cd", "c"}, readLines(t, ed, 4))
+	})
+
+	t.Run("history navigation and search", func(t *testing.T) {
+		ed := editor("first\r" + "second\r" + "\x1b[A\x1b[A\r" + "dra\x10\x0e\r" + "\x12sec\r" + "\x12f\x1b[C!\r")
+		lines := readLines(t, ed, 6)
+		require.Equal(t, []string{"first", "second", "first", "dra", "second", "first!"}, lines)
+		require.Equal(t, []string{"first", "second", "first", "dra", "second", "first!"}, ed.History())
+	})
+
+	t.Run("interrupts and end of input", func(t *testing.T) {
+		ed := editor("abc\x03\x04")
+		_, err := ed.ReadLine("> ")
+		require.ErrorIs(t, err, repl.ErrInterrupted)
+
+		_, err = ed.ReadLine("> ")
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("persistent history", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history")
+		ed := editor("(+ 1 2)\r\r(car x)\r")
+		require.NoError(t, ed.LoadHistory(path))
+		readLines(t, ed, 3)
+
+		bs, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, "(+ 1 2)\n(car x)\n", string(bs))
+
+		ed = editor("\x1b[A\x1b[A\r")
+		require.NoError(t, ed.LoadHistory(path))
+		require.Equal(t, []string{"(+ 1 2)"}, readLines(t, ed, 1))
+	})
+
+	t.Run("tab completion", func(t *testing.T) {
+		r := repl.New(strings.NewReader(""), io.Discard)
+		start, names := r.Complete([]rune("(vector-ref"), 11)
+		require.Equal(t, 1, start)
+		require.Equal(t, []string{"vector-ref"}, names)
+
+		_, names = r.Complete([]rune("(vector-c"), 9)
+		require.Equal(t, []string{"vector-copy", "vector-copy!"}, names)
+
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "scripts"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "script.scm"), nil, 0o644))
+
+		line := []rune(`(load "` + dir + `/scr`)
+		start, names = r.Complete(line, len(line))
+		require.Equal(t, len(line)-3, start)
+		require.Equal(t, []string{"script.scm", "scripts/"}, names)
+
+		ed := editor("(vector-re\t 1)\r(load \"" + dir + "/script.\t\")\r")
+		ed.Complete = r.Complete
+		require.Equal(t, []string{"(vector-ref 1)", `(load "` + dir + `/script.scm")`}, readLines(t, ed, 2))
+	})
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/interp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+
+	t.Run("eval in the interaction environment", func(t *testing.T) {
+		result, err := run(t, `
+(define q 1)
+(eval '(set! q (+ q 1)) (interaction-environment))
+(eval '(define r (* q 10)))
+(list q r (eval ''sym))`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 20 sym)", result.(*types.Pair).String())
+	})
+
+	t.Run("restricted environment", func(t *testing.T) {
+		result, err := run(t, `
+(define env (environment '(only (scheme base) + *) '(prefix (scheme char) c:)))
+(eval '(define (area w h) (* w h)) env)
+(list (eval '(area 2 (+ 1 2)) env) (eval '(c:string-upcase "ok") env))`)
+		require.NoError(t, err)
+		require.Equal(t, "(6 OK)", result.(*types.Pair).String())
+
+		_, err = run(t, `
+(define env (environment '(only (scheme base) +)))
+(eval '(car '(1 2)) env)`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+
+		_, err = run(t, `
+(define env (environment '(only (scheme base) +)))
+(eval '(define secret 1) env)
+secret`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+
+	t.Run("restricted environment can not import", func(t *testing.T) {
+		for _, code := range []string{
+			`(eval '(begin (import (scheme process-context)) (exit 42)) (environment '(only (scheme base) car)))`,
+			`(eval '(begin (import (scheme eval) (scheme repl)) (eval '(define car 99) (interaction-environment))) (null-environment 5))`,
+			`(eval '(define-library (escape) (import (scheme process-context)) (begin (exit 42))) (null-environment 5))`,
+			`(define (f) (import (scheme base))) (f)`,
+		} {
+			_, err := run(t, code)
+			require.ErrorIs(t, err, errscm.ErrBadSyntax, code)
+		}
+
+		path := filepath.Join(t.TempDir(), "secret.txt")
+		require.NoError(t, os.WriteFile(path, []byte("supersecrettoken"), 0o644))
+
+		_, err := run(t, `(eval '(include "`+path+`") (environment '(scheme base)))`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+		require.NotContains(t, err.Error(), "supersecrettoken")
+
+		interp.SetSearchPath(filepath.Dir(path))
+		t.Cleanup(func() { interp.SetSearchPath() })
+		require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "leak.sld"), []byte(`(exit 42)`), 0o644))
+
+		result, err := run(t, `(eval '(cond-expand ((library (leak)) 1) (else 2)) (environment '(scheme base)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+
+		result, err = run(t, `(eval '(begin (import (only (scheme base) car)) (car '(1 2))) (interaction-environment))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.Value())
+	})
+
+	t.Run("report environments", func(t *testing.T) {
+		result, err := run(t, `
+(list (eval '(if #t (quote yes) 0) (null-environment 5))
+      (eval '(string-downcase "AB") (scheme-report-environment 5)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(yes ab)", result.(*types.Pair).String())
+
+		_, err = run(t, `(eval '(+ 1 2) (null-environment 5))`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+
+		_, err = run(t, `(null-environment 7)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+
+	t.Run("load into environment", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rules.scm")
+		require.NoError(t, os.WriteFile(path, []byte(`(define limit (+ 40 2))`), 0o644))
+
+		result, err := run(t, `
+(define env (environment '(scheme base)))
+(load "`+path+`" env)
+(eval 'limit env)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), result.Value())
+
+		_, err = run(t, `(eval 1 2)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExceptions(t *testing.T) {
+
+	t.Run("error objects", func(t *testing.T) {
+		result, err := run(t, `(guard (e (#t (error-object-message e))) (error "boom" 1 2))`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("boom"), result)
+
+		result, err = run(t, `(guard (e ((error-object? e) (error-object-irritants e))) (error "boom" 1 2))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2)", result.(*types.Pair).String())
+
+		result, err = run(t, `(guard (e ((error-object? e) (file-error? e))) (error "boom"))`)
+		require.NoError(t, err)
+		require.Equal(t, types.Boolean(false), result)
+	})
+
+	t.Run("internal errors are catchable", func(t *testing.T) {
+		for _, code := range []string{
+			`(undefined-variable)`,
+			`(+ 1 "one")`,
+			`((lambda (x) x))`,
+			`(vector-ref #(1 2) 5)`,
+			`(/ 1 0)`,
+		} {
+			result, err := run(t, `(guard (e ((error-object? e) "caught")) `+code+`)`)
+			require.NoError(t, err, code)
+			require.Equal(t, types.String("caught"), result, code)
+		}
+
+		result, err := run(t, `(guard (e (#t (error-object-message e))) (undefined-variable))`)
+		require.NoError(t, err)
+		require.Contains(t, string(result.(types.String)), "undefined-variable")
+
+		result, err = run(t, `(guard (e (#t (error-object-message e))) (/ 1 0))`)
+		require.NoError(t, err)
+		require.Contains(t, string(result.(types.String)), "division by zero")
+	})
+
+	t.Run("raise any object", func(t *testing.T) {
+		result, err := run(t, `(guard (e ((= e 42) "forty-two")) (raise 42))`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("forty-two"), result)
+
+		result, err = run(t, `(guard (e (e => (lambda (x) (* x 2)))) (raise 21))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), result.Value())
+
+		result, err = run(t, `(guard (e ((= e 1) "one") (else "other")) (raise 2))`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("other"), result)
+	})
+
+	t.Run("guard re-raises unhandled objects", func(t *testing.T) {
+		result, err := run(t, `
+(guard (e (#t (+ 100 e)))
+  (guard (e ((= e 1) "one"))
+    (raise 2)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(102), result.Value())
+
+		result, err = run(t, `
+(with-exception-handler
+  (lambda (e) 10)
+  (lambda ()
+    (+ 1 (guard (e ((= e 1) "one"))
+           (raise-continuable 2)))))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(11), result.Value())
+	})
+
+	t.Run("raise-continuable", func(t *testing.T) {
+		result, err := run(t, `
+(with-exception-handler
+  (lambda (c) 42)
+  (lambda () (+ (raise-continuable "oops") 1)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(43), result.Value())
+	})
+
+	t.Run("handler runs with the outer handlers", func(t *testing.T) {
+		result, err := run(t, `
+(with-exception-handler
+  (lambda (c) (+ c 1))
+  (lambda ()
+    (with-exception-handler
+      (lambda (c) (raise-continuable (* c 10)))
+      (lambda () (raise-continuable 4)))))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(41), result.Value())
+	})
+
+	t.Run("handler returning from raise", func(t *testing.T) {
+		_, err := run(t, `(with-exception-handler (lambda (c) 0) (lambda () (raise 1)))`)
+		require.ErrorIs(t, err, errscm.ErrNonContinuable)
+
+		result, err := run(t, `
+(guard (e ((error-object? e) (error-object-irritants e)))
+  (with-exception-handler (lambda (c) 0) (lambda () (raise 1))))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1)", result.(*types.Pair).String())
+	})
+
+	t.Run("uncaught exceptions", func(t *testing.T) {
+		_, err := run(t, `(raise 1)`)
+		require.ErrorIs(t, err, errscm.ErrUncaughtException)
+
+		_, err = run(t, `(guard (e (#f "never")) (undefined-variable))`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+
+	t.Run("guard leaves dynamic-wind extents", func(t *testing.T) {
+		result, err := run(t, `
+(define trace (list))
+(define (note x) (set! trace (cons x trace)))
+(guard (e (#t (note e)))
+  (dynamic-wind
+    (lambda () (note 1))
+    (lambda () (raise 2))
+    (lambda () (note 3))))
+trace`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 3 1)", result.(*types.Pair).String())
+	})
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashTables(t *testing.T) {
+
+	t.Run("set, ref and delete", func(t *testing.T) {
+		result, err := run(t, `
+(define ht (make-hash-table))
+(hash-table-set! ht 'a 1 "b" 2)
+(hash-table-set! ht (list 1 2) 3)
+(hash-table-set! ht 'a 10)
+(define deleted (hash-table-delete! ht "b" 'missing))
+(list (hash-table-ref ht 'a)
+      (hash-table-ref ht (list 1 2))
+      (hash-table-ref/default ht "b" 'none)
+      (hash-table-ref ht "b" (lambda () 'failed))
+      (hash-table-ref ht 'a (lambda () 'failed) (lambda (v) (* v 2)))
+      (hash-table-contains? ht 'a)
+      (hash-table-exists? ht "b")
+      deleted
+      (hash-table-count ht)
+      (hash-table? ht))`)
+		require.NoError(t, err)
+		require.Equal(t, "(10 3 none failed 20 #t #f 1 2 #t)", result.(*types.Pair).String())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		result, err := run(t, `
+(define ht (make-hash-table equal?))
+(hash-table-update!/default ht 'n (lambda (v) (+ v 1)) 0)
+(hash-table-update!/default ht 'n (lambda (v) (+ v 1)) 0)
+(hash-table-update! ht 'm (lambda (v) (* v 10)) (lambda () 5))
+(hash-table-update! ht 'm (lambda (v) (+ v 1)))
+(list (hash-table-ref ht 'n) (hash-table-ref ht 'm))`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 51)", result.(*types.Pair).String())
+
+		_, err = run(t, `(hash-table-update! (make-hash-table) 'k (lambda (v) v))`)
+		require.ErrorIs(t, err, errscm.ErrKeyNotFound)
+	})
+
+	t.Run("keys, values, alist and walk keep insertion order", func(t *testing.T) {
+		result, err := run(t, `
+(define ht (make-hash-table))
+(hash-table-set! ht 'c 3 'a 1 'b 2)
+(hash-table-delete! ht 'a)
+(hash-table-set! ht 'a 4)
+(define sum 0)
+(hash-table-walk ht (lambda (k v) (set! sum (+ sum v))))
+(list (hash-table-keys ht) (hash-table-values ht) (hash-table->alist ht) sum
+      (hash-table-count (lambda (k v) (> v 2)) ht))`)
+		require.NoError(t, err)
+		require.Equal(t, "((c b a) (3 2 4) ((c . 3) (b . 2) (a . 4)) 9 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("records and vectors as keys", func(t *testing.T) {
+		result, err := run(t, point+`
+(define ht (make-hash-table))
+(hash-table-set! ht (make-point 1 2) 'p #(1 "x") 'v)
+(list (hash-table-ref ht (make-point 1 2)) (hash-table-ref ht #(1 "x")))`)
+		require.NoError(t, err)
+		require.Equal(t, "(p v)", result.(*types.Pair).String())
+	})
+
+	t.Run("eqv? tables", func(t *testing.T) {
+		result, err := run(t, `
+(define ht (make-hash-table eqv?))
+(define key (list 1))
+(hash-table-set! ht key 1 2 'two)
+(list (hash-table-ref/default ht (list 1) 'none) (hash-table-ref ht key) (hash-table-ref ht 2))`)
+		require.NoError(t, err)
+		require.Equal(t, "(none 1 two)", result.(*types.Pair).String())
+	})
+
+	t.Run("custom equality and hash", func(t *testing.T) {
+		result, err := run(t, `
+(define calls 0)
+(define (mod10= a b) (set! calls (+ calls 1)) (= (- a (* 10 (/ a 10))) (- b (* 10 (/ b 10)))))
+(define (mod10-hash n) (- n (* 10 (/ n 10))))
+(define ht (make-hash-table mod10= mod10-hash))
+(hash-table-set! ht 1 'one 12 'two)
+(hash-table-set! ht 21 'twenty-one)
+(define unhashed (make-hash-table mod10=))
+(hash-table-set! unhashed 3 'three)
+(list (hash-table-ref ht 11) (hash-table-count ht) (hash-table-ref unhashed 13) (> calls 0))`)
+		require.NoError(t, err)
+		require.Equal(t, "(twenty-one 2 three #t)", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define ht (make-hash-table equal? string-hash))
+(hash-table-set! ht "k" 1)
+(hash-table-ref ht "k")`)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.Value())
+	})
+
+	t.Run("hashing is consistent with equal?", func(t *testing.T) {
+		result, err := run(t, point+`
+(list (= (equal-hash (list 1 "a" #(b))) (equal-hash (list 1 "a" #(b))))
+      (= (equal-hash (make-point 1 2)) (equal-hash (make-point 1 2)))
+      (= (equal-hash #u8(1 2)) (equal-hash #u8(1 2)))
+      (= (string-hash "abc") (string-hash "abc"))
+      (< (string-hash "abc" 10) 10))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#t #t #t #t #t)", result.(*types.Pair).String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := run(t, `(hash-table-ref (make-hash-table) 'missing)`)
+		require.ErrorIs(t, err, errscm.ErrKeyNotFound)
+
+		_, err = run(t, `(hash-table-set! 5 1 2)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+
+		_, err = run(t, `(hash-table-set! (make-hash-table) 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+
+		_, err = run(t, `(string-hash 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLet(t *testing.T) {
+
+	t.Run("let evaluates initialisers in the outer scope", func(t *testing.T) {
+		result, err := run(t, `
+(define x 10)
+(let ((x 1) (y x)) (+ x y))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(11), result.Value())
+	})
+
+	t.Run("let* binds sequentially", func(t *testing.T) {
+		result, err := run(t, `
+(define x 10)
+(let* ((x 1) (y x)) (+ x y))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+	})
+
+	t.Run("bindings do not leak", func(t *testing.T) {
+		_, err := run(t, `
+(let ((x 1)) (define y 2) (+ x y))
+y`)
+		require.Error(t, err)
+
+		_, err = run(t, `
+(define (f) (define z 3) z)
+(f)
+z`)
+		require.Error(t, err)
+	})
+
+	t.Run("closures keep their own scope", func(t *testing.T) {
+		result, err := run(t, `
+(define (adder n) (lambda (x) (+ x n)))
+(define add5 (adder 5))
+(define add1 (adder 1))
+(list (add5 1) (add1 1))`)
+		require.NoError(t, err)
+		require.Equal(t, "(6 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("letrec allows mutual references", func(t *testing.T) {
+		result, err := run(t, `
+(letrec ((double (lambda (n) (* n 2)))
+         (quadruple (lambda (n) (double (double n)))))
+  (quadruple 3))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(12), result.Value())
+
+		result, err = run(t, `(letrec* ((a 1) (b (+ a 1))) (list a b))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("letrec use before initialisation", func(t *testing.T) {
+		_, err := run(t, `(letrec ((a b) (b 1)) a)`)
+		require.ErrorIs(t, err, errscm.ErrUseBeforeInit)
+
+		_, err = run(t, `(letrec ((a 1) (b (+ a 1))) b)`)
+		require.ErrorIs(t, err, errscm.ErrUseBeforeInit)
+	})
+
+	t.Run("named let binds loop procedure", func(t *testing.T) {
+		result, err := run(t, `(let loop ((a 2) (b 3)) (list a b loop))`)
+		require.NoError(t, err)
+
+		items, err := types.ListToSlice(result)
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		require.Equal(t, int64(2), items[0].Value())
+		require.Implements(t, (*types.Callable)(nil), items[2])
+	})
+
+	t.Run("malformed bindings", func(t *testing.T) {
+		_, err := run(t, `(let (x 1) x)`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+
+		for _, code := range []string{
+			`(let ((x 1) (x 2)) x)`,
+			`(let loop ((x 1) (x 2)) x)`,
+			`(letrec ((x 1) (x 2)) x)`,
+			`(letrec* ((x 1) (x 2)) x)`,
+		} {
+			_, err = run(t, code)
+			require.ErrorIs(t, err, errscm.ErrBadSyntax, code)
+		}
+
+		result, err := run(t, `(let* ((x 1) (x (+ x 1))) x)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+	})
+}
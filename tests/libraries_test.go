@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/interp"
+	"github.com/stretchr/testify/require"
+)
+
+const counter = `
+(define-library (test counter)
+  (export make-counter (rename counter-next next!) twice)
+  (import (scheme base))
+  (begin
+    (define step 1)
+    (define (make-counter) (vector 0))
+    (define (counter-next c)
+      (vector-set! c 0 (+ (vector-ref c 0) step))
+      (vector-ref c 0))
+    (define-syntax twice
+      (syntax-rules ()
+        ((_ e) (begin e e))))))
+`
+
+func TestLibraries(t *testing.T) {
+
+	t.Run("exports and renames", func(t *testing.T) {
+		result, err := run(t, counter+`
+(import (test counter))
+(define c (make-counter))
+(twice (next! c))
+(next! c)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.Value())
+	})
+
+	t.Run("library bindings are private", func(t *testing.T) {
+		_, err := run(t, counter+`
+(import (test counter))
+step`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+
+	t.Run("library body sees imports only", func(t *testing.T) {
+		_, err := run(t, `
+(define-library (test empty)
+  (export f)
+  (begin (define (f) (+ 1 2))))
+(import (test empty))
+(f)`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+
+	t.Run("program that imports sees imports only", func(t *testing.T) {
+		_, err := run(t, `
+(import (except (scheme base) car))
+(car '(1))`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+
+		result, err := run(t, `
+(define x 2)
+(import (only (scheme base) +))
+(+ x 1)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.Value())
+	})
+
+	t.Run("undefined export", func(t *testing.T) {
+		_, err := run(t, `
+(define-library (test broken)
+  (export missing)
+  (import (scheme base)))`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+
+	t.Run("only, except, prefix and rename", func(t *testing.T) {
+		result, err := run(t, counter+`
+(import (prefix (only (test counter) make-counter next!) c:))
+(import (rename (except (test counter) twice) (next! advance!)))
+(define c (c:make-counter))
+(c:next! c)
+(advance! c)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+
+		_, err = run(t, counter+`
+(import (only (test counter) step))`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+	})
+
+	t.Run("standard libraries", func(t *testing.T) {
+		result, err := run(t, `
+(define-library (test shout)
+  (export shout)
+  (import (only (scheme base) string->symbol)
+          (scheme char))
+  (begin
+    (define (shout s) (string->symbol (string-upcase s)))))
+(import (test shout))
+(shout "hey")`)
+		require.NoError(t, err)
+		require.Equal(t, types.Symbol("HEY"), result)
+
+		result, err = run(t, `
+(import (scheme char))
+(string-downcase "AB")`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("ab"), result)
+
+		_, err = run(t, `(import (scheme nonexistent))`)
+		require.ErrorIs(t, err, errscm.ErrUnknownLibrary)
+	})
+
+	t.Run("cond-expand", func(t *testing.T) {
+		result, err := run(t, `
+(cond-expand
+  ((and r7rs (not no-such-feature)) (define x 1))
+  (else (define x 2)))
+(cond-expand
+  ((library (no such library)) x)
+  ((or no-such-feature (library (scheme base))) (+ x 10)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(11), result.Value())
+
+		result, err = run(t, `
+(define-library (test features)
+  (export answer)
+  (cond-expand
+    (gopherscm (import (scheme base)) (begin (define answer 42)))
+    (else (begin (define answer 0)))))
+(import (test features))
+answer`)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), result.Value())
+
+		for _, code := range []string{
+			`(cond-expand (1 2))`,
+			`(cond-expand ("x" 2))`,
+			`(cond-expand (() 2))`,
+			`(define-library (x) (cond-expand (1 (begin 1))))`,
+		} {
+			_, err = run(t, code)
+			require.ErrorIs(t, err, errscm.ErrBadSyntax, code)
+		}
+	})
+
+	t.Run("include", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "defs.scm")
+		require.NoError(t, os.WriteFile(file, []byte("(define (square x) (* x x))"), 0o644))
+
+		result, err := run(t, `
+(define-library (test square)
+  (export square)
+  (import (scheme base))
+  (include "`+file+`"))
+(import (only (scheme base) *) (test square))
+(include "`+file+`")
+(square 7)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(49), result.Value())
+
+		_, err = run(t, `(include "`+filepath.Join(dir, "missing.scm")+`")`)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestLibrarySearchPath(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, code string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(code), 0o644))
+		return path
+	}
+
+	interp.SetSearchPath(filepath.Join(dir, "missing"), dir)
+	t.Cleanup(func() { interp.SetSearchPath() })
+
+	t.Run("libraries are loaded from files", func(t *testing.T) {
+		write("mylib/utils.sld", `
+(define-library (mylib utils)
+  (export double)
+  (import (scheme base) (mylib math))
+  (begin (define (double x) (times x 2))))`)
+		write("mylib/math.scm", `
+(define-library (mylib math)
+  (export times)
+  (import (scheme base))
+  (begin (define (times a b) (* a b))))`)
+
+		result, err := run(t, `
+(import (mylib utils))
+(double 21)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), result.Value())
+	})
+
+	t.Run("import cycles", func(t *testing.T) {
+		write("cyc/a.sld", `
+(define-library (cyc a)
+  (import (cyc b)))`)
+		write("cyc/b.sld", `
+(define-library (cyc b)
+  (import (cyc a)))`)
+
+		_, err := run(t, `(import (cyc a))`)
+		require.ErrorIs(t, err, errscm.ErrImportCycle)
+		require.Contains(t, err.Error(), "(cyc a) -> (cyc b) -> (cyc a)")
+	})
+
+	t.Run("file not defining the library", func(t *testing.T) {
+		write("other/lib.sld", `(define x 1)`)
+
+		_, err := run(t, `(import (other lib))`)
+		require.ErrorIs(t, err, errscm.ErrUnknownLibrary)
+	})
+
+	t.Run("modified files are parsed again", func(t *testing.T) {
+		path := write("value.scm", `(define value 1)`)
+		result, err := run(t, `(include "`+path+`") value`)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.Value())
+
+		write("value.scm", `(define value 2)`)
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		result, err = run(t, `(include "`+path+`") value`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+	})
+}
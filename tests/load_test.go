@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, code string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(code), 0o644))
+		return path
+	}
+
+	t.Run("include resolves paths relative to the including file", func(t *testing.T) {
+		write("sub/inner.scm", `(define inner 2)`)
+		write("sub/outer.scm", `(include "inner.scm") (define outer (+ inner 1))`)
+		main := write("main.scm", `(include "sub/outer.scm")`)
+
+		result, err := run(t, `(include "`+main+`") (list inner outer)`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("include splices definitions into the body", func(t *testing.T) {
+		path := write("body.scm", `(define x 10) (define y 20)`)
+
+		result, err := run(t, `
+(define (f)
+  (include "`+path+`")
+  (+ x y))
+(f)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(30), result.Value())
+	})
+
+	t.Run("load evaluates the file at runtime", func(t *testing.T) {
+		write("lib/helpers.scm", `(define (triple x) (* 3 x))`)
+		path := write("lib/main.scm", `(load "helpers.scm") (define loaded (triple 5))`)
+
+		result, err := run(t, `
+(define (go) (load "`+path+`"))
+(go)
+loaded`)
+		require.NoError(t, err)
+		require.Equal(t, int64(15), result.Value())
+	})
+
+	t.Run("errors name the file", func(t *testing.T) {
+		path := write("broken.scm", "(define a 1)\n(car a)")
+
+		_, err := run(t, `(load "`+path+`")`)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ERROR in "+path+" at line 2, position 0")
+
+		_, err = run(t, `(include "`+path+`")`)
+		require.Contains(t, err.Error(), "ERROR in "+path+" at line 2")
+
+		_, err = run(t, `(load "`+filepath.Join(dir, "missing.scm")+`")`)
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		_, err = run(t, `(load 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+}
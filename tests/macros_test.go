@@ -0,0 +1,341 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntaxRules(t *testing.T) {
+
+	t.Run("simple macro", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax swap!
+  (syntax-rules ()
+    ((_ a b) (let ((tmp a)) (set! a b) (set! b tmp)))))
+(define x 1)
+(define y 2)
+(swap! x y)
+(list x y)`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("ellipsis", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax my-or
+  (syntax-rules ()
+    ((_) #f)
+    ((_ e) e)
+    ((_ e r ...) (let ((t e)) (if t t (my-or r ...))))))
+(list (my-or) (my-or #f 2) (my-or #f #f 3 (error "not evaluated")))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#f 2 3)", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define-syntax my-let
+  (syntax-rules ()
+    ((_ ((name val) ...) body1 body2 ...)
+     ((lambda (name ...) body1 body2 ...) val ...))))
+(my-let ((a 1) (b 2)) (+ a b))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.Value())
+	})
+
+	t.Run("nested ellipsis and patterns after ellipsis", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax flatten
+  (syntax-rules ()
+    ((_ (a ...) ...) (list a ... ...))))
+(define-syntax last-of
+  (syntax-rules ()
+    ((_ x ... y) y)))
+(list (flatten (1 2) () (3)) (last-of 1 2 3))`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 2 3) 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("literals", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax for
+  (syntax-rules (in from to)
+    ((_ x in lst body ...) (vector-for-each (lambda (x) body ...) lst))
+    ((_ x from a to b body ...) (do ((x a (+ x 1))) ((> x b)) body ...))))
+(define sum 0)
+(for x in (vector 1 2 3) (set! sum (+ sum x)))
+(for i from 1 to 4 (set! sum (+ sum i)))
+sum`)
+		require.NoError(t, err)
+		require.Equal(t, int64(16), result.Value())
+	})
+
+	t.Run("custom ellipsis", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax my-list
+  (syntax-rules etc ()
+    ((_ x etc) (list x etc))))
+(my-list 1 2 3)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3)", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define-syntax my-list
+  (syntax-rules ::: ()
+    ((_ x :::) (list x :::))))
+(my-list 1 2 3)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("hygiene", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax my-or2
+  (syntax-rules ()
+    ((_ a b) (let ((t a)) (if t t b)))))
+(define t 5)
+(my-or2 #f t)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), result.Value())
+
+		result, err = run(t, `
+(define-syntax my-if
+  (syntax-rules ()
+    ((_ c a b) (cond (c a) (else b)))))
+(let ((if list) (else #f) (cond 1))
+  (my-if #f 1 2))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+
+		result, err = run(t, `
+(define-syntax first
+  (syntax-rules ()
+    ((_ l) (car l))))
+(let ((car cdr))
+  (first (list 1 2)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.Value())
+	})
+
+	t.Run("keywords are shadowed by bindings", func(t *testing.T) {
+		result, err := run(t, `
+(define (f do) (do 1))
+(list (let ((if list)) (if 1 2 3))
+      (f list)
+      (let ((quote -)) (quote 1)))`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 2 3) (1) -1)", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define-syntax if
+  (syntax-rules ()
+    ((_ c a b) (cond (c b) (else a)))))
+(if #t 1 2)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+
+		result, err = run(t, `
+(define-syntax my-when
+  (syntax-rules ()
+    ((_ c e) (if c e #f))))
+(let ((if list))
+  (my-when #t (if 1 2)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("literals match by binding", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax lit
+  (syntax-rules (=>)
+    ((_ a => b) (list a b))
+    ((_ a b c) 'nolit)))
+(list (lit 1 => 2)
+      (let ((=> 1)) (lit 1 => 2)))`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 2) nolit)", result.(*types.Pair).String())
+	})
+
+	t.Run("introduced definitions", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax define-counter
+  (syntax-rules ()
+    ((_ name) (begin (define count 0)
+                     (define (name) (set! count (+ count 1)) count)))))
+(define-counter next)
+(next)
+(define count 100)
+(list (next) count)`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 100)", result.(*types.Pair).String())
+	})
+
+	t.Run("let-syntax and letrec-syntax", func(t *testing.T) {
+		result, err := run(t, `
+(define x 0)
+(let ((x 1))
+  (let-syntax ((get-x (syntax-rules () ((_) x))))
+    (let ((x 2))
+      (get-x))))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.Value())
+
+		result, err = run(t, `
+(letrec-syntax
+    ((my-and (syntax-rules ()
+               ((_) #t)
+               ((_ e) e)
+               ((_ e r ...) (if e (my-and r ...) #f)))))
+  (list (my-and 1 2 3) (my-and 1 #f 3)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(3 #f)", result.(*types.Pair).String())
+	})
+
+	t.Run("macro defining macros", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax define-alias
+  (syntax-rules ()
+    ((_ name proc)
+     (define-syntax name
+       (syntax-rules ()
+         ((_ args (... ...)) (proc args (... ...))))))))
+(define-alias plus +)
+(plus 1 2 3)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), result.Value())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := run(t, `
+(define-syntax two (syntax-rules () ((_ a b) (list a b))))
+(two 1)`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+
+		_, err = run(t, `
+(define-syntax two (syntax-rules () ((_ a b) (list a b))))
+two`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+
+		_, err = run(t, `(define-syntax bad (syntax-rules () ((_ a ...) (list a))))
+(bad 1 2)`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+	})
+}
+
+func TestExplicitRenaming(t *testing.T) {
+
+	t.Run("hygienic swap", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax swap!
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (let ((a (car (cdr form)))
+            (b (car (cdr (cdr form)))))
+        (list (rename 'let) (list (list (rename 'tmp) a))
+              (list (rename 'set!) a b)
+              (list (rename 'set!) b (rename 'tmp)))))))
+(define tmp 1)
+(define y 2)
+(swap! tmp y)
+(list tmp y)`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("computed identifiers", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax define-getter
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (let ((name (syntax->datum (car (cdr form)))))
+        (list (rename 'define)
+              (list (symbol-append 'get- name))
+              (car (cdr (cdr form))))))))
+(define-getter x 42)
+(list (get-x) (identifier? 'x))`)
+		require.NoError(t, err)
+		require.Equal(t, "(42 #f)", result.(*types.Pair).String())
+	})
+
+	t.Run("compare and inspection of literals", func(t *testing.T) {
+		result, err := run(t, `
+(define-syntax my-if
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (let ((test (car (cdr form)))
+            (kw (car (cdr (cdr form)))))
+        (if (compare kw (rename 'then))
+            (list (rename 'if) test (car (cdr (cdr (cdr form)))) #f)
+            (error "expected then" (syntax->datum kw)))))))
+(list (my-if #t then 1) (my-if #f then 1))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 #f)", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define-syntax else?
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (compare (car (cdr form)) (rename 'else)))))
+(list (else? else) (else? other) (let ((else #f)) (else? else)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#t #f #f)", result.(*types.Pair).String())
+
+		_, err = run(t, `
+(define-syntax my-if
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (if (identifier? (car (cdr form))) (error "literal expected") (car (cdr form))))))
+(my-if x)`)
+		require.ErrorContains(t, err, "literal expected")
+	})
+
+	t.Run("let-syntax with procedural transformer", func(t *testing.T) {
+		result, err := run(t, `
+(let-syntax ((ten (er-macro-transformer (lambda (form rename compare) 10))))
+  (+ (ten) 1))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(11), result.Value())
+
+		_, err = run(t, `(define-syntax bad 5)`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+	})
+
+	t.Run("errors are reported at the macro use", func(t *testing.T) {
+		_, err := run(t, `
+(define-syntax call-it
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (list (car (cdr form))))))
+
+(call-it undefined-procedure)`)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+		require.ErrorContains(t, err, "line 7, position 9")
+
+		_, err = run(t, `
+(define-syntax twice (syntax-rules () ((_ e) (begin e e))))
+(define x 1)
+   (twice (car x))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+		require.ErrorContains(t, err, "line 4, position 10")
+
+		_, err = run(t, `
+(define-syntax my-begin
+  (er-macro-transformer
+    (lambda (form rename compare)
+      (cons (rename 'begin) (cdr form)))))
+
+(my-begin
+  1
+  (list 2
+        (car 3)))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+		require.ErrorContains(t, err, "line 10, position 8")
+	})
+}
+
+func TestQuote(t *testing.T) {
+	result, err := run(t, `(list 'a '(b . c) (quote (1 "s" #(d))) (symbol? 'a) (symbol->string 'abc) (string->symbol "x"))`)
+	require.NoError(t, err)
+	require.Equal(t, "(a (b . c) (1 s #(d)) #t abc x)", result.(*types.Pair).String())
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionalArguments(t *testing.T) {
+
+	t.Run("keyword and marker lexing", func(t *testing.T) {
+		ts, err := lexer.Lex([]rune("(f #!optional #:name name: :::)"))
+		require.NoErrorf(t, err, "expected no err, got: %v", err)
+		expected := data.TokenStream{
+			data.NewToken("(", data.Syntax),
+			data.NewToken("f", data.Id),
+			data.NewToken("#!optional", data.Id),
+			data.NewToken("name", data.Keyword),
+			data.NewToken("name", data.Keyword),
+			data.NewToken(":::", data.Id),
+			data.NewToken(")", data.Syntax),
+		}
+		require.Equal(t, len(expected), len(ts))
+
+		for i, tkn := range ts {
+			require.Equal(t, tkn.Type(), expected[i].Type())
+			require.Equal(t, tkn.Value(), expected[i].Value())
+		}
+	})
+
+	t.Run("case-lambda dispatches on argument count", func(t *testing.T) {
+		result, err := run(t, `
+(define area
+  (case-lambda
+    ((r) (* 3 r r))
+    ((w h) (* w h))
+    ((a b . rest) rest)))
+(list (area 2) (area 2 3) (area 1 2 3 4))`)
+		require.NoError(t, err)
+		require.Equal(t, "(12 6 (3 4))", result.(*types.Pair).String())
+
+		_, err = run(t, `((case-lambda ((a) a) ((a b c) a)))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+		require.Contains(t, err.Error(), "expected 1 or 3 args, got 0")
+	})
+
+	t.Run("optional parameters", func(t *testing.T) {
+		result, err := run(t, `
+(define* (f a #!optional (b 2) (c (+ a b)) d) (list a b c d))
+(list (f 1) (f 1 5) (f 1 5 0 7))`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 2 3 #f) (1 5 6 #f) (1 5 0 7))", result.(*types.Pair).String())
+
+		_, err = run(t, `((lambda* (a #!optional b) a) 1 2 3)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+	})
+
+	t.Run("keyword parameters", func(t *testing.T) {
+		result, err := run(t, `
+(define* (greet name #!key (greeting "Hello") (punct "!"))
+  (list greeting name punct))
+(list (greet "Bob") (greet "Bob" #:punct "?" greeting: "Hi"))`)
+		require.NoError(t, err)
+		require.Equal(t, "((Hello Bob !) (Hi Bob ?))", result.(*types.Pair).String())
+
+		_, err = run(t, `((lambda* (#!key a) a) #:b 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnknownKeyword)
+	})
+
+	t.Run("optional and rest parameters", func(t *testing.T) {
+		result, err := run(t, `((lambda* (a #:optional (b 0) #:rest r) (list a b r)) 1 2 3 4)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 (3 4))", result.(*types.Pair).String())
+	})
+
+	t.Run("keywords are self-evaluating", func(t *testing.T) {
+		result, err := run(t, `(list #:a b:)`)
+		require.NoError(t, err)
+		require.Equal(t, "(#:a #:b)", result.(*types.Pair).String())
+	})
+
+	t.Run("procedure arity", func(t *testing.T) {
+		result, err := run(t, `
+(define (fixed a b) a)
+(define (variadic a . rest) a)
+(define* (opt a #!optional b #!key c) a)
+(list (procedure-arity fixed) (procedure-arity variadic) (procedure-arity opt))`)
+		require.NoError(t, err)
+		require.Equal(t, "((2 . 2) (1 . #f) (1 . 4))", result.(*types.Pair).String())
+
+		result, err = run(t, `(procedure-arity (case-lambda ((a) a) ((a b . c) a)))`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 . 1) (2 . #f))", result.(*types.Pair).String())
+	})
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/interp"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/Vallghall/gopherscm/internal/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameters(t *testing.T) {
+
+	t.Run("parameterize", func(t *testing.T) {
+		result, err := run(t, `
+(define p (make-parameter 10))
+(define q (make-parameter "q"))
+(list (p)
+      (parameterize ((p 20)) (p))
+      (parameterize ((p 1) (q 2))
+        (parameterize ((p 3))
+          (+ (p) (q))))
+      (p)
+      (q))`)
+		require.NoError(t, err)
+		require.Equal(t, "(10 20 5 10 q)", result.(*types.Pair).String())
+	})
+
+	t.Run("converter", func(t *testing.T) {
+		result, err := run(t, `
+(define p (make-parameter 10 (lambda (x) (* x 2))))
+(list (p) (parameterize ((p 3)) (p)) (p))`)
+		require.NoError(t, err)
+		require.Equal(t, "(20 6 20)", result.(*types.Pair).String())
+
+		_, err = run(t, `(define p (make-parameter 10 5))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+
+	t.Run("restored after errors", func(t *testing.T) {
+		result, err := run(t, `
+(define p (make-parameter 10))
+(list (guard (e (#t (p)))
+        (parameterize ((p 5)) (error "boom")))
+      (p))`)
+		require.NoError(t, err)
+		require.Equal(t, "(10 10)", result.(*types.Pair).String())
+
+		ts, err := lexer.Lex([]rune(`
+(define p (make-parameter 10))
+(parameterize ((p 5)) (undefined-procedure))`))
+		require.NoError(t, err)
+
+		ast := parser.Parse(ts)
+		_, err = interp.Walk(ast)
+		require.ErrorIs(t, err, errscm.ErrUnboundVariable)
+
+		p, ok := ast.Ctx.FindDef("p")
+		require.True(t, ok)
+		require.Equal(t, int64(10), p.(*types.Parameter).Get().Value())
+	})
+
+	t.Run("restored by continuations", func(t *testing.T) {
+		result, err := run(t, `
+(define p (make-parameter 10))
+(call/cc (lambda (k) (parameterize ((p 5)) (k 0))))
+(p)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), result.Value())
+
+		result, err = run(t, `
+(define p (make-parameter 10))
+(define k #f)
+(define count 0)
+(define seen (list))
+(parameterize ((p 5))
+  (call/cc (lambda (c) (set! k c)))
+  (set! seen (cons (p) seen)))
+(set! seen (cons (p) seen))
+(if (= count 0) (begin (set! count 1) (k #f)))
+seen`)
+		require.NoError(t, err)
+		require.Equal(t, "(5 10 5)", result.(*types.Pair).String())
+	})
+
+	t.Run("current output port", func(t *testing.T) {
+		result, err := run(t, `
+(define port (open-output-string))
+(parameterize ((current-output-port port))
+  (display "hello")
+  (newline)
+  (display 42))
+(get-output-string port)`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("hello\n42"), result)
+
+		_, err = run(t, `(parameterize ((current-output-port 1)) (display 1))`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+
+	t.Run("not a parameter", func(t *testing.T) {
+		_, err := run(t, `(parameterize ((car 1)) 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+}
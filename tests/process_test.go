@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/process"
+	"github.com/Vallghall/gopherscm/internal/core/stdio"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessContext(t *testing.T) {
+
+	t.Run("command line", func(t *testing.T) {
+		process.SetCommandLine("main.scm", "-v", "input.txt")
+		t.Cleanup(func() { process.SetCommandLine(os.Args...) })
+
+		result, err := run(t, `
+(import (only (scheme process-context) command-line))
+(command-line)`)
+		require.NoError(t, err)
+		require.Equal(t, `("main.scm" "-v" "input.txt")`, stdio.Written(result))
+	})
+
+	t.Run("exit status", func(t *testing.T) {
+		for code, status := range map[string]int{
+			"(exit)":    0,
+			"(exit #t)": 0,
+			"(exit #f)": 1,
+			"(exit 42)": 42,
+		} {
+			_, err := run(t, code)
+
+			var exit *errscm.ExitError
+			require.ErrorAs(t, err, &exit, code)
+			require.Equal(t, status, exit.Code, code)
+		}
+
+		for _, code := range []string{`(exit "no")`, `(exit 256)`, `(exit -1)`} {
+			_, err := run(t, code)
+			require.ErrorIs(t, err, errscm.ErrUnexpectedType, code)
+		}
+	})
+
+	// output - redirects the current output port for the test
+	output := func(t *testing.T) *strings.Builder {
+		var out strings.Builder
+		port := stdio.CurrentOutputPort.Get()
+		stdio.CurrentOutputPort.Set(types.NewOutputPort(&out))
+		t.Cleanup(func() { stdio.CurrentOutputPort.Set(port) })
+		return &out
+	}
+
+	t.Run("exit runs after thunks and can not be caught", func(t *testing.T) {
+		out := output(t)
+		_, err := run(t, `
+(guard (e (#t (display "caught ")))
+  (dynamic-wind
+    (lambda () #f)
+    (lambda ()
+      (dynamic-wind
+        (lambda () #f)
+        (lambda () (exit 2))
+        (lambda () (display "inner "))))
+    (lambda () (display "outer "))))
+(display "not reached")`)
+
+		var exit *errscm.ExitError
+		require.ErrorAs(t, err, &exit)
+		require.Equal(t, 2, exit.Code)
+		require.Equal(t, "inner outer ", out.String())
+	})
+
+	t.Run("emergency exit skips after thunks", func(t *testing.T) {
+		out := output(t)
+		_, err := run(t, `
+(dynamic-wind
+  (lambda () #f)
+  (lambda () (emergency-exit 5))
+  (lambda () (display "after")))`)
+
+		var exit *errscm.ExitError
+		require.ErrorAs(t, err, &exit)
+		require.True(t, exit.Emergency)
+		require.Equal(t, 5, exit.Code)
+		require.Empty(t, out.String())
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		t.Setenv("GOPHERSCM_TEST_VAR", "a=b")
+
+		result, err := run(t, `
+(list (get-environment-variable "GOPHERSCM_TEST_VAR")
+      (get-environment-variable "GOPHERSCM_NO_SUCH_VAR")
+      (let find ((env (get-environment-variables)))
+        (if (equal? (car (car env)) "GOPHERSCM_TEST_VAR")
+            (cdr (car env))
+            (find (cdr env)))))`)
+		require.NoError(t, err)
+		require.Equal(t, `("a=b" #f "a=b")`, stdio.Written(result))
+	})
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromises(t *testing.T) {
+
+	t.Run("memoization", func(t *testing.T) {
+		result, err := run(t, `
+(define count 0)
+(define p (delay (begin (set! count (+ count 1)) (* 6 7))))
+(list (promise? p) (force p) (force p) count)`)
+		require.NoError(t, err)
+		require.Equal(t, "(#t 42 42 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("reentrant force", func(t *testing.T) {
+		result, err := run(t, `
+(define count 0)
+(define x 5)
+(define p
+  (delay (begin (set! count (+ count 1))
+                (if (> count x) count (force p)))))
+(define first (force p))
+(set! x 10)
+(list first (force p))`)
+		require.NoError(t, err)
+		require.Equal(t, "(6 6)", result.(*types.Pair).String())
+	})
+
+	t.Run("make-promise and force of non-promises", func(t *testing.T) {
+		result, err := run(t, `
+(define p (make-promise 1))
+(list (force p) (promise? (make-promise p)) (force 2) (promise? 2))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 #t 2 #f)", result.(*types.Pair).String())
+	})
+
+	t.Run("delay-force in constant space", func(t *testing.T) {
+		result, err := run(t, `
+(define (loop n)
+  (delay-force (if (= n 0) (delay "done") (loop (- n 1)))))
+(force (loop 100000))`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("done"), result)
+	})
+
+	t.Run("errors are not memoized", func(t *testing.T) {
+		result, err := run(t, `
+(define fail #t)
+(define p (delay (if fail (error "not yet") "ok")))
+(define first (guard (e (#t (error-object-message e))) (force p)))
+(set! fail #f)
+(list first (force p))`)
+		require.NoError(t, err)
+		require.Equal(t, "(not yet ok)", result.(*types.Pair).String())
+	})
+}
+
+func TestStreams(t *testing.T) {
+	const integers = `
+(define (integers-from n)
+  (stream-cons n (integers-from (+ n 1))))
+`
+
+	t.Run("unbounded stream", func(t *testing.T) {
+		result, err := run(t, integers+`
+(define s (integers-from 1))
+(list (stream-car s) (stream-car (stream-cdr (stream-cdr s))))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("stream-take and stream->list", func(t *testing.T) {
+		result, err := run(t, integers+`(stream->list (stream-take 5 (integers-from 1)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3 4 5)", result.(*types.Pair).String())
+
+		result, err = run(t, integers+`(stream->list 3 (integers-from 10))`)
+		require.NoError(t, err)
+		require.Equal(t, "(10 11 12)", result.(*types.Pair).String())
+
+		result, err = run(t, `(stream->list (stream-take 5 (stream-cons 1 (stream-cons 2 stream-null))))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("elements are evaluated lazily once", func(t *testing.T) {
+		result, err := run(t, `
+(define count 0)
+(define s (stream-cons (begin (set! count (+ count 1)) count) (error "never forced")))
+(list (stream-car s) (stream-car s) count (stream-pair? s) (stream-null? stream-null))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 1 1 #t #t)", result.(*types.Pair).String())
+	})
+
+	t.Run("user defined stream procedures", func(t *testing.T) {
+		result, err := run(t, integers+`
+(define (stream-filter keep? s)
+  (cond ((stream-null? s) stream-null)
+        ((keep? (stream-car s))
+         (stream-cons (stream-car s) (stream-filter keep? (stream-cdr s))))
+        (else (stream-filter keep? (stream-cdr s)))))
+(define (multiple-of-7? n) (= n (* 7 (/ n 7))))
+(stream->list 3 (stream-filter multiple-of-7? (integers-from 1)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(7 14 21)", result.(*types.Pair).String())
+	})
+
+	t.Run("stream-car of the empty stream", func(t *testing.T) {
+		_, err := run(t, `(stream-car stream-null)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/stdio"
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+const point = `
+(define-record-type <point>
+  (make-point x y)
+  point?
+  (x point-x set-point-x!)
+  (y point-y))
+`
+
+func TestRecords(t *testing.T) {
+
+	t.Run("constructor, predicate and accessors", func(t *testing.T) {
+		result, err := run(t, point+`
+(define p (make-point 1 2))
+(list (point? p) (point? 5) (point-x p) (point-y p))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#t #f 1 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("modifiers", func(t *testing.T) {
+		result, err := run(t, point+`
+(define p (make-point 1 2))
+(set-point-x! p 10)
+(point-x p)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), result.Value())
+	})
+
+	t.Run("printing", func(t *testing.T) {
+		result, err := run(t, point+`
+(define port (open-output-string))
+(display (make-point 1 2) port)
+(get-output-string port)`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("#<record point x: 1 y: 2>"), result)
+	})
+
+	t.Run("equivalence", func(t *testing.T) {
+		result, err := run(t, point+`
+(define-record-type other (make-other x y) other? (x other-x) (y other-y))
+(define p (make-point 1 (list 2 3)))
+(list (equal? p (make-point 1 (list 2 3)))
+      (eqv? p (make-point 1 (list 2 3)))
+      (eq? p p)
+      (equal? p (make-point 1 2))
+      (equal? (make-other 1 2) (make-point 1 2))
+      (equal? (list p) (list (make-point 1 (list 2 3)))))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#t #f #t #f #f #t)", result.(*types.Pair).String())
+	})
+
+	t.Run("partial and absent constructors", func(t *testing.T) {
+		result, err := run(t, `
+(define-record-type node (make-node value) node? (value node-value) (next node-next set-node-next!))
+(define-record-type pair2 pair2 pair2? (a pair2-a) (b pair2-b))
+(define-record-type abstract #f abstract?)
+(define n (make-node 1))
+(set-node-next! n 2)
+(list (node-value n) (node-next n) (pair2-b (pair2 1 2)) (abstract? n))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 2 #f)", result.(*types.Pair).String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := run(t, point+`(point-x 5)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+
+		_, err = run(t, point+`(make-point 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+
+		_, err = run(t, `(define-record-type p (make-p z) p? (x p-x))`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+	})
+}
+
+func TestEquivalence(t *testing.T) {
+	result, err := run(t, `
+(list (eqv? 1 1) (eqv? 1 1.0) (eqv? 'a 'a) (eqv? "s" "s") (eqv? car car) (eqv? car cdr)
+      (equal? (list 1 #(2 "x")) (list 1 #(2 "x"))) (equal? #u8(1 2) #u8(1 2)) (equal? '() '()))`)
+	require.NoError(t, err)
+	require.Equal(t, "(#t #f #t #t #t #f #t #t #t)", result.(*types.Pair).String())
+}
+
+func TestCircularStructures(t *testing.T) {
+	const circular = `
+(define v (vector 1))
+(vector-set! v 0 v)
+(define-record-type node (make-node next) node? (next node-next set-node-next!))
+(define n (make-node #f))
+(set-node-next! n n)
+`
+
+	t.Run("equal? terminates", func(t *testing.T) {
+		result, err := run(t, circular+`
+(list (equal? v (vector v)) (equal? v (vector (vector 1)))
+      (equal? n (make-node n)) (equal? n (make-node #f)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(#t #f #t #f)", result.(*types.Pair).String())
+	})
+
+	t.Run("printing labels cycles", func(t *testing.T) {
+		result, err := run(t, circular+`(list v n v "s")`)
+		require.NoError(t, err)
+		require.Equal(t, `(#0=#(#0#) #1=#<record node next: #1#> #0# "s")`, stdio.Written(result))
+		require.Equal(t, `(#0=#(#0#) #1=#<record node next: #1#> #0# s)`, result.(*types.Pair).String())
+	})
+}
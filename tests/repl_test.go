@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/repl"
+	"github.com/stretchr/testify/require"
+)
+
+// session - runs the REPL over the input and returns its output
+func session(t *testing.T, input string) string {
+	t.Helper()
+
+	var out strings.Builder
+	repl.New(strings.NewReader(input), &out).Run()
+	return out.String()
+}
+
+func TestREPL(t *testing.T) {
+
+	t.Run("multi-line input and persistent definitions", func(t *testing.T) {
+		out := session(t, "(define (sq x)\n  (* x x))\n(sq 5)\n(list \"a\" 'b)\n")
+		require.Contains(t, out, "gopherscm> ... gopherscm> 25\n")
+		require.Contains(t, out, `("a" b)`)
+	})
+
+	t.Run("errors do not end the session", func(t *testing.T) {
+		out := session(t, "(car 1)\n(+ 1 2)\n")
+		require.Contains(t, out, "expected pair, got 1")
+		require.Contains(t, out, "gopherscm> 3\n")
+	})
+
+	t.Run("unspecified values are not printed", func(t *testing.T) {
+		out := session(t, "(define x 1)\n(values)\n(values 1 2)\n")
+		require.Contains(t, out, "gopherscm> gopherscm> gopherscm> 1 2\n")
+	})
+
+	t.Run("procedures are printed with their names", func(t *testing.T) {
+		out := session(t, "car\n(list vector-map call/cc)\n(define (sq x) (* x x))\nsq\n")
+		require.Contains(t, out, "gopherscm> #<procedure car>\n")
+		require.Contains(t, out, "(#<procedure vector-map> #<procedure call-with-current-continuation>)\n")
+		require.Contains(t, out, "#<procedure sq>\n")
+	})
+
+	t.Run("meta-commands", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "defs.scm")
+		require.NoError(t, os.WriteFile(path, []byte("(define loaded 7)"), 0o644))
+
+		out := session(t, ",load "+path+"\n,env\n,time (+ loaded 1)\n,help\n,nope\n,quit\n(+ 1 1)\n")
+		require.Contains(t, out, "loaded = 7\n")
+		require.Contains(t, out, "8\n;; ")
+		require.Contains(t, out, ",load <file>")
+		require.Contains(t, out, "unknown command ,nope")
+		require.NotContains(t, out, "2\n")
+	})
+
+	t.Run("exit ends the session with its status", func(t *testing.T) {
+		var out strings.Builder
+		err := repl.New(strings.NewReader("(exit 3)\n(+ 1 1)\n"), &out).Run()
+
+		var exit *errscm.ExitError
+		require.ErrorAs(t, err, &exit)
+		require.Equal(t, 3, exit.Code)
+		require.NotContains(t, out.String(), "2\n")
+	})
+}
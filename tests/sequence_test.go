@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequence(t *testing.T) {
+
+	t.Run("boolean lexing", func(t *testing.T) {
+		ts, err := lexer.Lex([]rune("(and #t #false)"))
+		require.NoErrorf(t, err, "expected no err, got: %v", err)
+		expected := data.TokenStream{
+			data.NewToken("(", data.Syntax),
+			data.NewToken("and", data.Id),
+			data.NewToken("t", data.Boolean),
+			data.NewToken("f", data.Boolean),
+			data.NewToken(")", data.Syntax),
+		}
+		require.Equal(t, len(expected), len(ts))
+
+		for i, tkn := range ts {
+			require.Equal(t, tkn.Type(), expected[i].Type())
+			require.Equal(t, tkn.Value(), expected[i].Value())
+		}
+	})
+
+	t.Run("begin returns the last value", func(t *testing.T) {
+		result, err := run(t, `(begin 1 2 3)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.Value())
+	})
+
+	t.Run("top-level begin splices definitions", func(t *testing.T) {
+		result, err := run(t, `
+(begin
+  (define a 1)
+  (define (inc x) (+ x 1)))
+(inc a)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.Value())
+	})
+
+	t.Run("do loop", func(t *testing.T) {
+		result, err := run(t, `
+(do ((vec (make-vector 5))
+     (i 0 (+ i 1)))
+    ((= i 5) vec)
+  (vector-set! vec i i))`)
+		require.NoError(t, err)
+		require.Equal(t, "#(0 1 2 3 4)", result.(*types.Vector).String())
+
+		result, err = run(t, `
+(do ((i 0 (+ i 1))
+     (acc (list) (cons i acc)))
+    ((>= i 3) acc))`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 1 0)", result.(*types.Pair).String())
+	})
+
+	t.Run("do loop closures capture iteration values", func(t *testing.T) {
+		result, err := run(t, `
+(define procs (make-vector 3))
+(do ((i 0 (+ i 1)))
+    ((= i 3))
+  (vector-set! procs i (lambda () i)))
+(list ((vector-ref procs 0)) ((vector-ref procs 2)))`)
+		require.NoError(t, err)
+		require.Equal(t, "(0 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("and", func(t *testing.T) {
+		result, err := run(t, `(and)`)
+		require.NoError(t, err)
+		require.Equal(t, types.Boolean(true), result)
+
+		result, err = run(t, `(and 1 2 "c")`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("c"), result)
+
+		result, err = run(t, `(and 1 #f undefined-var)`)
+		require.NoError(t, err)
+		require.Equal(t, types.Boolean(false), result)
+	})
+
+	t.Run("or", func(t *testing.T) {
+		result, err := run(t, `(or)`)
+		require.NoError(t, err)
+		require.Equal(t, types.Boolean(false), result)
+
+		result, err = run(t, `(or #f (< 2 1) 7 undefined-var)`)
+		require.NoError(t, err)
+		require.Equal(t, int64(7), result.Value())
+	})
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailCalls(t *testing.T) {
+	// a loop that grows the Go stack would exceed the limit
+	// and crash long before reaching the final iteration
+	defer debug.SetMaxStack(debug.SetMaxStack(2 << 20))
+
+	t.Run("if", func(t *testing.T) {
+		result, err := run(t, `
+(define (count-down n)
+  (if (= n 0)
+      "done"
+      (count-down (- n 1))))
+(count-down 100000)`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("done"), result)
+	})
+
+	t.Run("mutual recursion through cond", func(t *testing.T) {
+		result, err := run(t, `
+(define (even? n)
+  (cond ((= n 0) #t)
+        (else (odd? (- n 1)))))
+(define (odd? n)
+  (cond ((= n 0) #f)
+        (else (even? (- n 1)))))
+(even? 100001)`)
+		require.NoError(t, err)
+		require.Equal(t, types.Boolean(false), result)
+	})
+
+	t.Run("named let with accumulator", func(t *testing.T) {
+		result, err := run(t, `
+(let loop ((i 0) (acc 0))
+  (if (< i 100000)
+      (let ((next (+ i 1)))
+        (loop next (+ acc 1)))
+      acc))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(100000), result.Value())
+	})
+
+	t.Run("and/or and begin", func(t *testing.T) {
+		result, err := run(t, `
+(define (walk n)
+  (or (= n 0)
+      (and #t (begin (walk (- n 1))))))
+(walk 100000)`)
+		require.NoError(t, err)
+		require.Equal(t, types.Boolean(true), result)
+	})
+
+	t.Run("cond receiver", func(t *testing.T) {
+		result, err := run(t, `
+(define (loop n)
+  (cond ((= n 0) "done")
+        ((- n 1) => loop)))
+(loop 100000)`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("done"), result)
+	})
+
+	t.Run("if without alternative", func(t *testing.T) {
+		result, err := run(t, `(if #f 1)`)
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+}
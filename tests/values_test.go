@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValues(t *testing.T) {
+
+	t.Run("apply", func(t *testing.T) {
+		result, err := run(t, `(apply + 1 2 (list 3 4))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), result.Value())
+
+		result, err = run(t, `(apply list (list))`)
+		require.NoError(t, err)
+		require.Equal(t, types.Null, result)
+
+		_, err = run(t, `(apply + 1 2)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedType)
+	})
+
+	t.Run("apply in tail position", func(t *testing.T) {
+		result, err := run(t, `
+(define (loop n)
+  (if (= n 0) "done" (apply loop (list (- n 1)))))
+(loop 100000)`)
+		require.NoError(t, err)
+		require.Equal(t, types.String("done"), result)
+	})
+
+	t.Run("call-with-values", func(t *testing.T) {
+		result, err := run(t, `(call-with-values (lambda () (values 1 2 3)) list)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3)", result.(*types.Pair).String())
+
+		result, err = run(t, `(call-with-values (lambda () (values)) list)`)
+		require.NoError(t, err)
+		require.Equal(t, types.Null, result)
+
+		result, err = run(t, `(call-with-values (lambda () 5) (lambda (x) (* x x)))`)
+		require.NoError(t, err)
+		require.Equal(t, int64(25), result.Value())
+	})
+
+	t.Run("let-values", func(t *testing.T) {
+		result, err := run(t, `
+(define (div-mod a b) (values (/ a b) (- a (* b (/ a b)))))
+(let-values (((q r) (div-mod 7 2))
+             ((first . rest) (values 1 2 3))
+             (all (values)))
+  (list q r first rest all))`)
+		require.NoError(t, err)
+		require.Equal(t, "(3 1 1 (2 3) ())", result.(*types.Pair).String())
+	})
+
+	t.Run("let*-values", func(t *testing.T) {
+		result, err := run(t, `
+(let*-values (((a b) (values 1 2))
+              ((c) (values (+ a b))))
+  (list a b c))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("define-values and receive", func(t *testing.T) {
+		result, err := run(t, `
+(define-values (x y . z) (values 1 2 3 4))
+(receive (a . rest) (values x y z)
+  (list a rest))`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 (2 (3 4)))", result.(*types.Pair).String())
+	})
+
+	t.Run("wrong number of values", func(t *testing.T) {
+		_, err := run(t, `(+ 1 (values 2 3))`)
+		require.ErrorIs(t, err, errscm.ErrWrongNumberOfValues)
+
+		_, err = run(t, `(define x (values))`)
+		require.ErrorIs(t, err, errscm.ErrWrongNumberOfValues)
+
+		_, err = run(t, `(if (values 1 2) 1 2)`)
+		require.ErrorIs(t, err, errscm.ErrWrongNumberOfValues)
+
+		_, err = run(t, `(let-values (((a b) (values 1 2 3))) a)`)
+		require.ErrorIs(t, err, errscm.ErrWrongNumberOfValues)
+		require.Contains(t, err.Error(), "expected 2 values, got 3")
+	})
+}
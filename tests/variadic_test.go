@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariadic(t *testing.T) {
+
+	t.Run("dot lexing", func(t *testing.T) {
+		ts, err := lexer.Lex([]rune("(a . rest)"))
+		require.NoErrorf(t, err, "expected no err, got: %v", err)
+		expected := data.TokenStream{
+			data.NewToken("(", data.Syntax),
+			data.NewToken("a", data.Id),
+			data.NewToken(".", data.Syntax),
+			data.NewToken("rest", data.Id),
+			data.NewToken(")", data.Syntax),
+		}
+		require.Equal(t, len(expected), len(ts))
+
+		for i, tkn := range ts {
+			require.Equal(t, tkn.Type(), expected[i].Type())
+			require.Equal(t, tkn.Value(), expected[i].Value())
+		}
+	})
+
+	t.Run("define with rest parameter", func(t *testing.T) {
+		result, err := run(t, `
+(define (f a b . rest) (list a b rest))
+(list (f 1 2) (f 1 2 3 4))`)
+		require.NoError(t, err)
+		require.Equal(t, "((1 2 ()) (1 2 (3 4)))", result.(*types.Pair).String())
+
+		result, err = run(t, `
+(define (g . args) args)
+(g 1 2 3)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 2 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("lambda with rest parameter", func(t *testing.T) {
+		result, err := run(t, `((lambda args args))`)
+		require.NoError(t, err)
+		require.Equal(t, types.Null, result)
+
+		result, err = run(t, `((lambda (a . rest) (cons rest a)) 1 2 3)`)
+		require.NoError(t, err)
+		require.Equal(t, "((2 3) . 1)", result.(*types.Pair).String())
+	})
+
+	t.Run("vector literals keep dotted lists", func(t *testing.T) {
+		result, err := run(t, `(vector-ref #((1 . 2)) 0)`)
+		require.NoError(t, err)
+		require.Equal(t, "(1 . 2)", result.(*types.Pair).String())
+	})
+
+	t.Run("arity errors", func(t *testing.T) {
+		_, err := run(t, `
+(define (two a b) a)
+(two 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+		require.Contains(t, err.Error(), "two expected 2 args, got 1")
+
+		_, err = run(t, `
+(define (at-least-two a b . rest) a)
+(at-least-two 1)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+		require.Contains(t, err.Error(), "at-least-two expected at least 2 args, got 1")
+
+		_, err = run(t, `((lambda (x) x) 1 2)`)
+		require.ErrorIs(t, err, errscm.ErrUnexpectedNumberOfArguments)
+		require.Contains(t, err.Error(), "expected 1 args, got 2")
+	})
+
+	t.Run("misplaced dot", func(t *testing.T) {
+		_, err := run(t, `(lambda (a . b c) a)`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+
+		_, err = run(t, `(define (f . ) 1)`)
+		require.ErrorIs(t, err, errscm.ErrBadSyntax)
+	})
+}
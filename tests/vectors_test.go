@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Vallghall/gopherscm/internal/core/types"
+	"github.com/Vallghall/gopherscm/internal/data"
+	"github.com/Vallghall/gopherscm/internal/errscm"
+	"github.com/Vallghall/gopherscm/internal/interp"
+	"github.com/Vallghall/gopherscm/internal/lexer"
+	"github.com/Vallghall/gopherscm/internal/parser"
+	"github.com/stretchr/testify/require"
+)
+
+// run - lexes, parses and evaluates the given code
+func run(t *testing.T, code string) (types.Object, error) {
+	t.Helper()
+
+	ts, err := lexer.Lex([]rune(code))
+	require.NoError(t, err)
+
+	return interp.Walk(parser.Parse(ts))
+}
+
+func TestVectors(t *testing.T) {
+
+	t.Run("vector literal lexing", func(t *testing.T) {
+		ts, err := lexer.Lex([]rune("#(1 (2) foo)"))
+		require.NoErrorf(t, err, "expected no err, got: %v", err)
+		expected := data.TokenStream{
+			data.NewToken("#(", data.Syntax),
+			data.NewToken("1", data.Int),
+			data.NewToken("(", data.Syntax),
+			data.NewToken("2", data.Int),
+			data.NewToken(")", data.Syntax),
+			data.NewToken("foo", data.Id),
+			data.NewToken(")", data.Syntax),
+		}
+		require.Equal(t, len(expected), len(ts))
+
+		for i, tkn := range ts {
+			require.Equal(t, tkn.Type(), expected[i].Type())
+			require.Equal(t, tkn.Value(), expected[i].Value())
+		}
+	})
+
+	t.Run("vector literal evaluation", func(t *testing.T) {
+		result, err := run(t, `#(1 "two" three (4 5) #())`)
+		require.NoError(t, err)
+		require.Equal(t, `#(1 two three (4 5) #())`, result.(*types.Vector).String())
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+		result, err := run(t, `
+(define v (make-vector 3 0))
+(vector-set! v 1 5)
+(vector-fill! v 9 2)
+v`)
+		require.NoError(t, err)
+		require.Equal(t, "#(0 5 9)", result.(*types.Vector).String())
+	})
+
+	t.Run("conversions", func(t *testing.T) {
+		result, err := run(t, `(vector->list (list->vector (list 1 2 3 4)) 1 3)`)
+		require.NoError(t, err)
+		require.Equal(t, "(2 3)", result.(*types.Pair).String())
+	})
+
+	t.Run("copying", func(t *testing.T) {
+		result, err := run(t, `
+(define v (vector 1 2 3 4 5))
+(vector-copy! v 0 v 2)
+(vector-append (vector-copy v 1 3) #(a))`)
+		require.NoError(t, err)
+		require.Equal(t, "#(4 5 a)", result.(*types.Vector).String())
+	})
+
+	t.Run("higher order procedures", func(t *testing.T) {
+		result, err := run(t, `
+(define (sq x) (* x x))
+(vector-map sq #(1 2 3))`)
+		require.NoError(t, err)
+		require.Equal(t, "#(1 4 9)", result.(*types.Vector).String())
+
+		result, err = run(t, `(vector-map + #(1 2 3) #(10 20))`)
+		require.NoError(t, err)
+		require.Equal(t, "#(11 22)", result.(*types.Vector).String())
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := run(t, `(vector-ref #(1 2 3) 3)`)
+		require.ErrorIs(t, err, errscm.ErrIndexOutOfRange)
+
+		_, err = run(t, `(vector-copy #(1 2 3) 2 1)`)
+		require.ErrorIs(t, err, errscm.ErrIndexOutOfRange)
+
+		_, err = run(t, `(vector-copy! (make-vector 2) 1 #(1 2))`)
+		require.ErrorIs(t, err, errscm.ErrIndexOutOfRange)
+	})
+
+	t.Run("length limit", func(t *testing.T) {
+		result, err := run(t, `
+(guard (e (#t 'too-long))
+  (make-vector 100000000000))`)
+		require.NoError(t, err)
+		require.Equal(t, types.Symbol("too-long"), result)
+
+		_, err = run(t, `(make-vector 100000000000 0)`)
+		require.ErrorIs(t, err, errscm.ErrIndexOutOfRange)
+	})
+}